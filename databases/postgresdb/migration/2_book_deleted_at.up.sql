@@ -0,0 +1 @@
+ALTER TABLE books ADD COLUMN deleted_at TIMESTAMP NULL;
@@ -0,0 +1,12 @@
+DROP INDEX IF EXISTS movies_title_key;
+CREATE TABLE movies_tmp (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    title VARCHAR (255) NOT NULL,
+    director VARCHAR (255) NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+INSERT INTO movies_tmp (id, title, director, updated_at, created_at)
+    SELECT id, title, director, updated_at, created_at FROM movies;
+DROP TABLE movies;
+ALTER TABLE movies_tmp RENAME TO movies;
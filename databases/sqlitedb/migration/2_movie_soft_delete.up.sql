@@ -0,0 +1,2 @@
+ALTER TABLE movies ADD COLUMN deleted_at TIMESTAMP NULL;
+CREATE UNIQUE INDEX movies_title_key ON movies (title);
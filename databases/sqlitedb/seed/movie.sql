@@ -0,0 +1,3 @@
+INSERT INTO movies (id, director, title) VALUES (1, 'Francis Ford Coppola', 'The Godfather');
+INSERT INTO movies (id, director, title) VALUES (2, 'Frank Darabont', 'The Shawshank Redemption');
+INSERT INTO movies (id, director, title) VALUES (3, 'Christopher Nolan', 'The Dark Knight');
@@ -0,0 +1,35 @@
+package data_access_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/typical-go/typical-go/pkg/typast"
+	"github.com/typical-go/typical-rest-server/pkg/typrepo"
+)
+
+// TestSchema make sure the schema state of latest migration is same with the @entity so `./typicalw migration`
+// doesn't generate unexpected migration
+func TestSchema(t *testing.T) {
+	for _, pkg := range []string{"postgresdb", "mysqldb", "sqlitedb"} {
+		t.Run(pkg, func(t *testing.T) {
+			summary, err := typast.Compile(fmt.Sprintf("%s/types.go", pkg))
+			require.NoError(t, err)
+
+			var entities []*typrepo.Entity
+			for _, annot := range summary.FindAnnot("@entity", typast.EqualStruct) {
+				entity, err := typrepo.CreateEntity(&typast.Annot2{Annot: annot})
+				require.NoError(t, err)
+				entities = append(entities, entity)
+			}
+			require.NotEmpty(t, entities)
+
+			prev, err := typrepo.ReadSchema(fmt.Sprintf("../../../databases/%s/schema.json", pkg))
+			require.NoError(t, err)
+			up, down := typrepo.DiffSchema(entities[0].Dialect, prev, typrepo.CreateSchema(entities))
+			require.Empty(t, up)
+			require.Empty(t, down)
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package sqlitedb_test
+
+import (
+	"context"
+	"database/sql"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/typical-go/typical-rest-server/internal/app/data_access/sqlitedb"
+	"github.com/typical-go/typical-rest-server/internal/generated/sqlitedb_repo"
+	"github.com/typical-go/typical-rest-server/pkg/sqkit"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// openDB return sqlite database (in temporary directory) which migrated with the up migration
+func openDB(t *testing.T) *sql.DB {
+	dir, err := ioutil.TempDir("", "sqlitedb")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	db, err := sql.Open("sqlite3", filepath.Join(dir, "test.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	ups, err := filepath.Glob("../../../../databases/sqlitedb/migration/*.up.sql")
+	require.NoError(t, err)
+	require.NotEmpty(t, ups)
+	for _, up := range ups { // NOTE: sorted by name and there is less than 10 migrations
+		b, err := ioutil.ReadFile(up)
+		require.NoError(t, err)
+		_, err = db.Exec(string(b))
+		require.NoError(t, err, up)
+	}
+	return db
+}
+
+func TestMovieRepo_UpsertAfterSoftDelete(t *testing.T) {
+	ctx := context.Background()
+	repo, err := sqlitedb_repo.NewMovieRepo(sqlitedb_repo.MovieRepoImpl{DB: openDB(t)})
+	require.NoError(t, err)
+
+	id, err := repo.Create(ctx, &sqlitedb.Movie{Title: "some-title", Director: "some-director"})
+	require.NoError(t, err)
+	affectedRow, err := repo.DeleteByPK(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), affectedRow)
+
+	upsertID, err := repo.Upsert(ctx, &sqlitedb.Movie{Title: "some-title", Director: "other-director"})
+	require.NoError(t, err)
+	require.Equal(t, id, upsertID)
+
+	movie, err := repo.FindByPK(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, "other-director", movie.Director)
+	require.Nil(t, movie.DeletedAt)
+
+	cnt, err := repo.Count(ctx, sqkit.Eq{sqlitedb_repo.MovieTable.Title: "some-title"})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), cnt)
+}
+
+func TestMovieRepo_SetPrimaryKey(t *testing.T) {
+	ctx := context.Background()
+	repo, err := sqlitedb_repo.NewMovieRepo(sqlitedb_repo.MovieRepoImpl{DB: openDB(t)})
+	require.NoError(t, err)
+
+	movie := &sqlitedb.Movie{Title: "some-title", Director: "some-director"}
+	id, err := repo.Create(ctx, movie)
+	require.NoError(t, err)
+	require.Equal(t, id, movie.ID)
+
+	inserted := &sqlitedb.Movie{Title: "other-title", Director: "some-director"}
+	insertedID, err := repo.Upsert(ctx, inserted)
+	require.NoError(t, err)
+	require.Equal(t, insertedID, inserted.ID)
+	require.NotEqual(t, id, insertedID)
+
+	updated := &sqlitedb.Movie{Title: "some-title", Director: "other-director"}
+	_, err = repo.Upsert(ctx, updated)
+	require.NoError(t, err)
+	require.Equal(t, id, updated.ID)
+}
+
+func TestNewMovieRepo_MissingDatabase(t *testing.T) {
+	_, err := sqlitedb_repo.NewMovieRepo(sqlitedb_repo.MovieRepoImpl{})
+	require.EqualError(t, err, "MovieRepo: missing database")
+}
@@ -0,0 +1,16 @@
+package sqlitedb
+
+import "time"
+
+type (
+	// Movie entity
+	// @entity (table:"movies" dialect:"sqlite" ctor_db:"sqlite")
+	Movie struct {
+		ID        int64      `column:"id" option:"pk" json:"id"`
+		Title     string     `column:"title" option:"unique" json:"title" validate:"required"`
+		Director  string     `column:"director" json:"director" validate:"required"`
+		UpdatedAt time.Time  `column:"updated_at" option:"now" json:"update_at"`
+		CreatedAt time.Time  `column:"created_at" option:"now,no_update" json:"created_at"`
+		DeletedAt *time.Time `column:"deleted_at" option:"soft_delete" json:"deleted_at,omitempty"`
+	}
+)
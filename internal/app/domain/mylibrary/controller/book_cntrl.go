@@ -1,14 +1,18 @@
 package controller
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/typical-go/typical-rest-server/internal/app/data_access/postgresdb"
 	"github.com/typical-go/typical-rest-server/internal/app/domain/mylibrary/service"
 	"github.com/typical-go/typical-rest-server/pkg/cachekit"
+	"github.com/typical-go/typical-rest-server/pkg/dbtxn"
 	"github.com/typical-go/typical-rest-server/pkg/echokit"
+	"github.com/typical-go/typical-rest-server/pkg/sqkit"
 	"go.uber.org/dig"
 )
 
@@ -46,6 +50,7 @@ func (c *BookCntrl) Create(ec echo.Context) (err error) {
 		return echokit.HTTPError(err)
 	}
 	ec.Response().Header().Set(echo.HeaderLocation, fmt.Sprintf("/books/%d", newBook.ID))
+	ec.Response().Header().Set(echokit.HeaderETag, bookETag(newBook))
 	return ec.JSON(http.StatusCreated, newBook)
 }
 
@@ -73,6 +78,7 @@ func (c *BookCntrl) FindOne(ec echo.Context) error {
 	if err != nil {
 		return echokit.HTTPError(err)
 	}
+	ec.Response().Header().Set(echokit.HeaderETag, bookETag(book))
 	return ec.JSON(http.StatusOK, book)
 }
 
@@ -80,8 +86,16 @@ func (c *BookCntrl) FindOne(ec echo.Context) error {
 func (c *BookCntrl) Delete(ec echo.Context) (err error) {
 	ctx := ec.Request().Context()
 	id := ec.Param("id")
-	if err = c.Svc.Delete(ctx, id); err != nil {
-		return echokit.HTTPError(err)
+	current, err := c.ifMatch(ec)
+	if err != nil {
+		return err
+	}
+	var version int64
+	if current != nil {
+		version = current.Version
+	}
+	if err = c.Svc.Delete(ctx, id, version); err != nil {
+		return preconditionError(current, err)
 	}
 	return ec.NoContent(http.StatusNoContent)
 }
@@ -94,24 +108,69 @@ func (c *BookCntrl) Update(ec echo.Context) (err error) {
 	}
 	ctx := ec.Request().Context()
 	paramID := ec.Param("id")
+	current, err := c.ifMatch(ec)
+	if err != nil {
+		return err
+	}
+	if current != nil {
+		book.Version = current.Version
+	}
 	updatedBook, err := c.Svc.Update(ctx, paramID, &book)
 	if err != nil {
-		return echokit.HTTPError(err)
+		return preconditionError(current, err)
 	}
+	ec.Response().Header().Set(echokit.HeaderETag, bookETag(updatedBook))
 	return ec.JSON(http.StatusOK, updatedBook)
 }
 
-// Patch book
+// Patch book. Field in JSON body is patched even with zero or null value
 func (c *BookCntrl) Patch(ec echo.Context) (err error) {
 	var book postgresdb.Book
-	if err = ec.Bind(&book); err != nil {
+	keys, err := echokit.BindPatch(ec, &book)
+	if err != nil {
 		return err
 	}
 	ctx := ec.Request().Context()
 	paramID := ec.Param("id")
-	patchedBook, err := c.Svc.Patch(ctx, paramID, &book)
+	current, err := c.ifMatch(ec)
 	if err != nil {
-		return echokit.HTTPError(err)
+		return err
 	}
+	if current != nil {
+		book.Version = current.Version
+	}
+	patchedBook, err := c.Svc.Patch(ctx, paramID, &book, sqkit.JSONColumns(&book, keys...)...)
+	if err != nil {
+		return preconditionError(current, err)
+	}
+	ec.Response().Header().Set(echokit.HeaderETag, bookETag(patchedBook))
 	return ec.JSON(http.StatusOK, patchedBook)
 }
+
+// ifMatch compare `If-Match` header with ETag of current book. Return the current book if header available
+func (c *BookCntrl) ifMatch(ec echo.Context) (*postgresdb.Book, error) {
+	ifMatch := ec.Request().Header.Get(echokit.HeaderIfMatch)
+	if ifMatch == "" {
+		return nil, nil
+	}
+	current, err := c.Svc.FindOne(dbtxn.ForcePrimary(ec.Request().Context()), ec.Param("id"))
+	if err != nil {
+		return nil, echokit.HTTPError(err)
+	}
+	if !echokit.MatchETag(ifMatch, bookETag(current)) {
+		return nil, echo.NewHTTPError(http.StatusPreconditionFailed)
+	}
+	return current, nil
+}
+
+// preconditionError return 412 if the book changed after `If-Match` checked
+func preconditionError(current *postgresdb.Book, err error) error {
+	if current != nil && errors.Is(err, sqkit.ErrVersionConflict) {
+		return echo.NewHTTPError(http.StatusPreconditionFailed)
+	}
+	return echokit.HTTPError(err)
+}
+
+func bookETag(book *postgresdb.Book) string {
+	return echokit.ETag(book.ID, book.Version, book.UpdatedAt.Format(time.RFC3339Nano))
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/typical-go/typical-rest-server/internal/app/domain/mylibrary/service_mock"
 	"github.com/typical-go/typical-rest-server/pkg/echokit"
 	"github.com/typical-go/typical-rest-server/pkg/echotest"
+	"github.com/typical-go/typical-rest-server/pkg/sqkit"
 )
 
 type (
@@ -56,9 +57,10 @@ func TestBookCntrl_FindOne(t *testing.T) {
 				},
 				ExpectedResponse: echotest.Response{
 					Code: http.StatusOK,
-					Body: "{\"id\":1,\"title\":\"title1\",\"author\":\"author1\",\"update_at\":\"0001-01-01T00:00:00Z\",\"created_at\":\"0001-01-01T00:00:00Z\"}\n",
+					Body: "{\"id\":1,\"title\":\"title1\",\"author\":\"author1\",\"version\":0,\"update_at\":\"0001-01-01T00:00:00Z\",\"created_at\":\"0001-01-01T00:00:00Z\"}\n",
 					Header: http.Header{
 						"Content-Type": {"application/json; charset=UTF-8"},
+						"Etag":         {"\"358aa95cec5e7056\""},
 					},
 				},
 			},
@@ -102,7 +104,7 @@ func TestBookCntrl_FindOne(t *testing.T) {
 					Target:    "/",
 					URLParams: map[string]string{"id": "2"},
 				},
-				ExpectedError: "code=500, message=some-error",
+				ExpectedError: "code=500, message=Internal Server Error, internal=some-error",
 			},
 			BookCntrlFn: func(svc *service_mock.MockBookSvc) {
 				svc.EXPECT().FindOne(gomock.Any(), "2").Return(nil, errors.New("some-error"))
@@ -129,7 +131,7 @@ func TestBookCntrl_Find(t *testing.T) {
 				},
 				ExpectedResponse: echotest.Response{
 					Code: http.StatusOK,
-					Body: "[{\"id\":1,\"title\":\"title1\",\"author\":\"author1\",\"update_at\":\"0001-01-01T00:00:00Z\",\"created_at\":\"0001-01-01T00:00:00Z\"},{\"id\":2,\"title\":\"title2\",\"author\":\"author2\",\"update_at\":\"0001-01-01T00:00:00Z\",\"created_at\":\"0001-01-01T00:00:00Z\"}]\n",
+					Body: "[{\"id\":1,\"title\":\"title1\",\"author\":\"author1\",\"version\":0,\"update_at\":\"0001-01-01T00:00:00Z\",\"created_at\":\"0001-01-01T00:00:00Z\"},{\"id\":2,\"title\":\"title2\",\"author\":\"author2\",\"version\":0,\"update_at\":\"0001-01-01T00:00:00Z\",\"created_at\":\"0001-01-01T00:00:00Z\"}]\n",
 					Header: http.Header{
 						"Content-Type":  {"application/json; charset=UTF-8"},
 						"X-Total-Count": {"10"},
@@ -154,7 +156,7 @@ func TestBookCntrl_Find(t *testing.T) {
 					Method: http.MethodGet,
 					Target: "/?limit=20&offset=10",
 				},
-				ExpectedError: "code=500, message=some-error",
+				ExpectedError: "code=500, message=Internal Server Error, internal=some-error",
 			},
 			BookCntrlFn: func(svc *service_mock.MockBookSvc) {
 				svc.EXPECT().
@@ -168,7 +170,7 @@ func TestBookCntrl_Find(t *testing.T) {
 					Method: http.MethodGet,
 					Target: "/?sort=name,created_at",
 				},
-				ExpectedError: "code=500, message=some-error",
+				ExpectedError: "code=500, message=Internal Server Error, internal=some-error",
 			},
 			BookCntrlFn: func(svc *service_mock.MockBookSvc) {
 				svc.EXPECT().
@@ -210,7 +212,7 @@ func TestBookCntrl_Update(t *testing.T) {
 					Header:    http.Header{"Content-Type": {"application/json"}},
 					Body:      `{"title":"some-title", "author": "some-author"}`,
 				},
-				ExpectedError: "code=500, message=some-error",
+				ExpectedError: "code=500, message=Internal Server Error, internal=some-error",
 			},
 			BookCntrlFn: func(svc *service_mock.MockBookSvc) {
 				svc.EXPECT().
@@ -218,6 +220,42 @@ func TestBookCntrl_Update(t *testing.T) {
 					Return(nil, errors.New("some-error"))
 			},
 		},
+		{
+			TestName: "version conflict",
+			TestCase: echotest.TestCase{
+				Request: echotest.Request{
+					Method:    http.MethodPut,
+					Target:    "/",
+					URLParams: map[string]string{"id": "1"},
+					Header:    http.Header{"Content-Type": {"application/json"}},
+					Body:      `{"title":"some-title", "author": "some-author", "version": 1}`,
+				},
+				ExpectedError: "code=409, message=version conflict, the resource has been modified, internal=sqkit: version conflict",
+			},
+			BookCntrlFn: func(svc *service_mock.MockBookSvc) {
+				svc.EXPECT().
+					Update(gomock.Any(), "1", &postgresdb.Book{ID: 1, Title: "some-title", Author: "some-author", Version: 1}).
+					Return(nil, sqkit.ErrVersionConflict)
+			},
+		},
+		{
+			TestName: "missing version",
+			TestCase: echotest.TestCase{
+				Request: echotest.Request{
+					Method:    http.MethodPut,
+					Target:    "/",
+					URLParams: map[string]string{"id": "1"},
+					Header:    http.Header{"Content-Type": {"application/json"}},
+					Body:      `{"title":"some-title", "author": "some-author"}`,
+				},
+				ExpectedError: "code=428, message=missing version of the resource, internal=sqkit: missing version",
+			},
+			BookCntrlFn: func(svc *service_mock.MockBookSvc) {
+				svc.EXPECT().
+					Update(gomock.Any(), "1", &postgresdb.Book{ID: 1, Title: "some-title", Author: "some-author"}).
+					Return(nil, sqkit.ErrMissingVersion)
+			},
+		},
 		{
 			TestCase: echotest.TestCase{
 				Request: echotest.Request{
@@ -229,9 +267,10 @@ func TestBookCntrl_Update(t *testing.T) {
 				},
 				ExpectedResponse: echotest.Response{
 					Code: http.StatusOK,
-					Body: "{\"id\":1,\"title\":\"some-title\",\"author\":\"some-author\",\"update_at\":\"0001-01-01T00:00:00Z\",\"created_at\":\"0001-01-01T00:00:00Z\"}\n",
+					Body: "{\"id\":1,\"title\":\"some-title\",\"author\":\"some-author\",\"version\":0,\"update_at\":\"0001-01-01T00:00:00Z\",\"created_at\":\"0001-01-01T00:00:00Z\"}\n",
 					Header: http.Header{
 						"Content-Type": {"application/json; charset=UTF-8"},
+						"Etag":         {"\"358aa95cec5e7056\""},
 					},
 				},
 			},
@@ -241,6 +280,76 @@ func TestBookCntrl_Update(t *testing.T) {
 					Return(&postgresdb.Book{ID: 1, Title: "some-title", Author: "some-author"}, nil)
 			},
 		},
+		{
+			TestName: "if-match not match",
+			TestCase: echotest.TestCase{
+				Request: echotest.Request{
+					Method:    http.MethodPut,
+					Target:    "/",
+					URLParams: map[string]string{"id": "1"},
+					Header: http.Header{
+						"Content-Type": {"application/json"},
+						"If-Match":     {"\"stale-etag\""},
+					},
+					Body: `{"title":"some-title", "author": "some-author"}`,
+				},
+				ExpectedError: "code=412, message=Precondition Failed",
+			},
+			BookCntrlFn: func(svc *service_mock.MockBookSvc) {
+				svc.EXPECT().FindOne(gomock.Any(), "1").Return(&postgresdb.Book{ID: 1, Version: 2}, nil)
+			},
+		},
+		{
+			TestName: "if-match changed after checked",
+			TestCase: echotest.TestCase{
+				Request: echotest.Request{
+					Method:    http.MethodPut,
+					Target:    "/",
+					URLParams: map[string]string{"id": "1"},
+					Header: http.Header{
+						"Content-Type": {"application/json"},
+						"If-Match":     {"\"5b15ddcf18408530\""},
+					},
+					Body: `{"title":"some-title", "author": "some-author"}`,
+				},
+				ExpectedError: "code=412, message=Precondition Failed",
+			},
+			BookCntrlFn: func(svc *service_mock.MockBookSvc) {
+				svc.EXPECT().FindOne(gomock.Any(), "1").Return(&postgresdb.Book{ID: 1, Version: 2}, nil)
+				svc.EXPECT().
+					Update(gomock.Any(), "1", &postgresdb.Book{ID: 1, Title: "some-title", Author: "some-author", Version: 2}).
+					Return(nil, sqkit.ErrVersionConflict)
+			},
+		},
+		{
+			TestName: "if-match",
+			TestCase: echotest.TestCase{
+				Request: echotest.Request{
+					Method:    http.MethodPut,
+					Target:    "/",
+					URLParams: map[string]string{"id": "1"},
+					Header: http.Header{
+						"Content-Type": {"application/json"},
+						"If-Match":     {"\"5b15ddcf18408530\""},
+					},
+					Body: `{"title":"some-title", "author": "some-author"}`,
+				},
+				ExpectedResponse: echotest.Response{
+					Code: http.StatusOK,
+					Body: "{\"id\":1,\"title\":\"some-title\",\"author\":\"some-author\",\"version\":3,\"update_at\":\"0001-01-01T00:00:00Z\",\"created_at\":\"0001-01-01T00:00:00Z\"}\n",
+					Header: http.Header{
+						"Content-Type": {"application/json; charset=UTF-8"},
+						"Etag":         {"\"5250438b33c6b317\""},
+					},
+				},
+			},
+			BookCntrlFn: func(svc *service_mock.MockBookSvc) {
+				svc.EXPECT().FindOne(gomock.Any(), "1").Return(&postgresdb.Book{ID: 1, Version: 2}, nil)
+				svc.EXPECT().
+					Update(gomock.Any(), "1", &postgresdb.Book{ID: 1, Title: "some-title", Author: "some-author", Version: 2}).
+					Return(&postgresdb.Book{ID: 1, Title: "some-title", Author: "some-author", Version: 3}, nil)
+			},
+		},
 	}
 
 	for _, tt := range testcases {
@@ -275,11 +384,11 @@ func TestBookCntrl_Patch(t *testing.T) {
 					Header:    http.Header{"Content-Type": {"application/json"}},
 					Body:      `{"title":"some-title", "author": "some-author"}`,
 				},
-				ExpectedError: "code=500, message=some-error",
+				ExpectedError: "code=500, message=Internal Server Error, internal=some-error",
 			},
 			BookCntrlFn: func(svc *service_mock.MockBookSvc) {
 				svc.EXPECT().
-					Patch(gomock.Any(), "1", &postgresdb.Book{ID: 1, Title: "some-title", Author: "some-author"}).
+					Patch(gomock.Any(), "1", &postgresdb.Book{ID: 1, Title: "some-title", Author: "some-author"}, "title", "author").
 					Return(nil, errors.New("some-error"))
 			},
 		},
@@ -294,18 +403,44 @@ func TestBookCntrl_Patch(t *testing.T) {
 				},
 				ExpectedResponse: echotest.Response{
 					Code: http.StatusOK,
-					Body: "{\"id\":1,\"title\":\"some-title\",\"author\":\"some-author\",\"update_at\":\"0001-01-01T00:00:00Z\",\"created_at\":\"0001-01-01T00:00:00Z\"}\n",
+					Body: "{\"id\":1,\"title\":\"some-title\",\"author\":\"some-author\",\"version\":0,\"update_at\":\"0001-01-01T00:00:00Z\",\"created_at\":\"0001-01-01T00:00:00Z\"}\n",
 					Header: http.Header{
 						"Content-Type": {"application/json; charset=UTF-8"},
+						"Etag":         {"\"358aa95cec5e7056\""},
 					},
 				},
 			},
 			BookCntrlFn: func(svc *service_mock.MockBookSvc) {
 				svc.EXPECT().
-					Patch(gomock.Any(), "1", &postgresdb.Book{ID: 1, Title: "some-title", Author: "some-author"}).
+					Patch(gomock.Any(), "1", &postgresdb.Book{ID: 1, Title: "some-title", Author: "some-author"}, "title", "author").
 					Return(&postgresdb.Book{ID: 1, Title: "some-title", Author: "some-author"}, nil)
 			},
 		},
+		{
+			TestName: "explicit zero value",
+			TestCase: echotest.TestCase{
+				Request: echotest.Request{
+					Method:    http.MethodPatch,
+					Target:    "/",
+					URLParams: map[string]string{"id": "1"},
+					Header:    http.Header{"Content-Type": {"application/json"}},
+					Body:      `{"author": ""}`,
+				},
+				ExpectedResponse: echotest.Response{
+					Code: http.StatusOK,
+					Body: "{\"id\":1,\"title\":\"some-title\",\"author\":\"\",\"version\":0,\"update_at\":\"0001-01-01T00:00:00Z\",\"created_at\":\"0001-01-01T00:00:00Z\"}\n",
+					Header: http.Header{
+						"Content-Type": {"application/json; charset=UTF-8"},
+						"Etag":         {"\"358aa95cec5e7056\""},
+					},
+				},
+			},
+			BookCntrlFn: func(svc *service_mock.MockBookSvc) {
+				svc.EXPECT().
+					Patch(gomock.Any(), "1", &postgresdb.Book{ID: 1}, "author").
+					Return(&postgresdb.Book{ID: 1, Title: "some-title"}, nil)
+			},
+		},
 	}
 
 	for _, tt := range testcases {
@@ -332,7 +467,7 @@ func TestBookCntrl_Delete(t *testing.T) {
 				},
 			},
 			BookCntrlFn: func(svc *service_mock.MockBookSvc) {
-				svc.EXPECT().Delete(gomock.Any(), "1").Return(nil)
+				svc.EXPECT().Delete(gomock.Any(), "1", int64(0)).Return(nil)
 			},
 		},
 		{
@@ -342,10 +477,10 @@ func TestBookCntrl_Delete(t *testing.T) {
 					Target:    "/",
 					URLParams: map[string]string{"id": "1"},
 				},
-				ExpectedError: "code=500, message=some-error",
+				ExpectedError: "code=500, message=Internal Server Error, internal=some-error",
 			},
 			BookCntrlFn: func(svc *service_mock.MockBookSvc) {
-				svc.EXPECT().Delete(gomock.Any(), "1").Return(errors.New("some-error"))
+				svc.EXPECT().Delete(gomock.Any(), "1", int64(0)).Return(errors.New("some-error"))
 			},
 		},
 		{
@@ -358,7 +493,57 @@ func TestBookCntrl_Delete(t *testing.T) {
 				ExpectedError: "code=422, message=some-validation",
 			},
 			BookCntrlFn: func(svc *service_mock.MockBookSvc) {
-				svc.EXPECT().Delete(gomock.Any(), "1").Return(echokit.NewValidErr("some-validation"))
+				svc.EXPECT().Delete(gomock.Any(), "1", int64(0)).Return(echokit.NewValidErr("some-validation"))
+			},
+		},
+		{
+			TestName: "if-match not match",
+			TestCase: echotest.TestCase{
+				Request: echotest.Request{
+					Method:    http.MethodDelete,
+					Target:    "/",
+					URLParams: map[string]string{"id": "1"},
+					Header:    http.Header{"If-Match": {"\"stale-etag\""}},
+				},
+				ExpectedError: "code=412, message=Precondition Failed",
+			},
+			BookCntrlFn: func(svc *service_mock.MockBookSvc) {
+				svc.EXPECT().FindOne(gomock.Any(), "1").Return(&postgresdb.Book{ID: 1, Version: 2}, nil)
+			},
+		},
+		{
+			TestName: "if-match changed after checked",
+			TestCase: echotest.TestCase{
+				Request: echotest.Request{
+					Method:    http.MethodDelete,
+					Target:    "/",
+					URLParams: map[string]string{"id": "1"},
+					Header:    http.Header{"If-Match": {"\"5b15ddcf18408530\""}},
+				},
+				ExpectedError: "code=412, message=Precondition Failed",
+			},
+			BookCntrlFn: func(svc *service_mock.MockBookSvc) {
+				svc.EXPECT().FindOne(gomock.Any(), "1").Return(&postgresdb.Book{ID: 1, Version: 2}, nil)
+				svc.EXPECT().Delete(gomock.Any(), "1", int64(2)).Return(sqkit.ErrVersionConflict)
+			},
+		},
+		{
+			TestName: "if-match",
+			TestCase: echotest.TestCase{
+				Request: echotest.Request{
+					Method:    http.MethodDelete,
+					Target:    "/",
+					URLParams: map[string]string{"id": "1"},
+					Header:    http.Header{"If-Match": {"\"5b15ddcf18408530\""}},
+				},
+				ExpectedResponse: echotest.Response{
+					Code:   http.StatusNoContent,
+					Header: http.Header{},
+				},
+			},
+			BookCntrlFn: func(svc *service_mock.MockBookSvc) {
+				svc.EXPECT().FindOne(gomock.Any(), "1").Return(&postgresdb.Book{ID: 1, Version: 2}, nil)
+				svc.EXPECT().Delete(gomock.Any(), "1", int64(2)).Return(nil)
 			},
 		},
 	}
@@ -393,7 +578,7 @@ func TestBookCntrl_Create(t *testing.T) {
 					Body:   `{"author":"some-author", "title":"some-title"}`,
 					Header: http.Header{"Content-Type": {"application/json"}},
 				},
-				ExpectedError: "code=500, message=some-error",
+				ExpectedError: "code=500, message=Internal Server Error, internal=some-error",
 			},
 			BookCntrlFn: func(svc *service_mock.MockBookSvc) {
 				svc.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("some-error"))
@@ -408,10 +593,11 @@ func TestBookCntrl_Create(t *testing.T) {
 					Header: http.Header{"Content-Type": {"application/json"}},
 				},
 				ExpectedResponse: echotest.Response{
-					Body: "{\"id\":999,\"title\":\"some-title\",\"author\":\"some-author\",\"update_at\":\"0001-01-01T00:00:00Z\",\"created_at\":\"0001-01-01T00:00:00Z\"}\n",
+					Body: "{\"id\":999,\"title\":\"some-title\",\"author\":\"some-author\",\"version\":0,\"update_at\":\"0001-01-01T00:00:00Z\",\"created_at\":\"0001-01-01T00:00:00Z\"}\n",
 					Code: http.StatusCreated,
 					Header: http.Header{
 						"Content-Type": {"application/json; charset=UTF-8"},
+						"Etag":         {"\"f15d74a816cdc3cc\""},
 						"Location":     {"/books/999"},
 					},
 				},
@@ -11,6 +11,7 @@ import (
 
 	"github.com/typical-go/typical-rest-server/internal/app/data_access/postgresdb"
 	"github.com/typical-go/typical-rest-server/internal/generated/postgresdb_repo"
+	"github.com/typical-go/typical-rest-server/pkg/dbtxn"
 	"github.com/typical-go/typical-rest-server/pkg/echokit"
 	"github.com/typical-go/typical-rest-server/pkg/sqkit"
 	"go.uber.org/dig"
@@ -24,9 +25,9 @@ type (
 		FindOne(context.Context, string) (*postgresdb.Book, error)
 		Find(context.Context, *FindBookReq) (*FindBookResp, error)
 		Create(context.Context, *postgresdb.Book) (*postgresdb.Book, error)
-		Delete(context.Context, string) error
+		Delete(context.Context, string, int64) error
 		Update(context.Context, string, *postgresdb.Book) (*postgresdb.Book, error)
-		Patch(context.Context, string, *postgresdb.Book) (*postgresdb.Book, error)
+		Patch(context.Context, string, *postgresdb.Book, ...string) (*postgresdb.Book, error)
 	}
 	// BookSvcImpl is implementation of BookSvc
 	BookSvcImpl struct {
@@ -61,7 +62,7 @@ func (b *BookSvcImpl) Create(ctx context.Context, book *postgresdb.Book) (*postg
 	if err != nil {
 		return nil, err
 	}
-	return b.findOne(ctx, id)
+	return b.findOne(dbtxn.ForcePrimary(ctx), id)
 }
 
 // Find books
@@ -101,15 +102,23 @@ func (b *BookSvcImpl) findOne(ctx context.Context, id int64) (*postgresdb.Book,
 	return books[0], nil
 }
 
-// Delete book
-func (b *BookSvcImpl) Delete(ctx context.Context, paramID string) error {
+// Delete book. Non-zero version only delete the book with same version, otherwise return sqkit.ErrVersionConflict
+func (b *BookSvcImpl) Delete(ctx context.Context, paramID string, version int64) error {
 	id, _ := strconv.ParseInt(paramID, 10, 64)
-	_, err := b.Repo.Delete(ctx, sqkit.Eq{postgresdb_repo.BookTable.ID: id})
+	cond := sqkit.Eq{postgresdb_repo.BookTable.ID: id}
+	if version != 0 {
+		cond[postgresdb_repo.BookTable.Version] = version
+	}
+	affectedRow, err := b.Repo.Delete(ctx, cond)
+	if err == nil && version != 0 && affectedRow < 1 {
+		err = sqkit.ErrVersionConflict
+	}
 	return err
 }
 
 // Update book
 func (b *BookSvcImpl) Update(ctx context.Context, paramID string, book *postgresdb.Book) (*postgresdb.Book, error) {
+	ctx = dbtxn.ForcePrimary(ctx)
 	id, _ := strconv.ParseInt(paramID, 10, 64)
 	if err := validator.New().Struct(book); err != nil {
 		return nil, echokit.NewValidErr(err.Error())
@@ -129,30 +138,31 @@ func (b *BookSvcImpl) update(ctx context.Context, id int64, book *postgresdb.Boo
 		return err
 	}
 	if affectedRow < 1 {
-		return errors.New("no affected row")
+		return echokit.Wrap(echokit.ErrNotFound, errors.New("no affected row"))
 	}
 	return nil
 }
 
-// Patch book
-func (b *BookSvcImpl) Patch(ctx context.Context, paramID string, book *postgresdb.Book) (*postgresdb.Book, error) {
+// Patch book. Only non-zero field is patched unless columns is given
+func (b *BookSvcImpl) Patch(ctx context.Context, paramID string, book *postgresdb.Book, columns ...string) (*postgresdb.Book, error) {
+	ctx = dbtxn.ForcePrimary(ctx)
 	id, _ := strconv.ParseInt(paramID, 10, 64)
 	if _, err := b.findOne(ctx, id); err != nil {
 		return nil, err
 	}
-	if err := b.patch(ctx, id, book); err != nil {
+	if err := b.patch(ctx, id, book, columns...); err != nil {
 		return nil, err
 	}
 	return b.findOne(ctx, id)
 }
 
-func (b *BookSvcImpl) patch(ctx context.Context, id int64, book *postgresdb.Book) error {
-	affectedRow, err := b.Repo.Patch(ctx, book, sqkit.Eq{postgresdb_repo.BookTable.ID: id})
+func (b *BookSvcImpl) patch(ctx context.Context, id int64, book *postgresdb.Book, columns ...string) error {
+	affectedRow, err := b.Repo.Patch(ctx, book, sqkit.Eq{postgresdb_repo.BookTable.ID: id}, columns...)
 	if err != nil {
 		return err
 	}
 	if affectedRow < 1 {
-		return errors.New("no affected row")
+		return echokit.Wrap(echokit.ErrNotFound, errors.New("no affected row"))
 	}
 	return nil
 }
@@ -2,6 +2,7 @@ package service_test
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"testing"
 
@@ -13,9 +14,21 @@ import (
 	"github.com/typical-go/typical-rest-server/internal/generated/postgresdb_repo_mock"
 
 	"github.com/typical-go/typical-rest-server/internal/app/domain/mylibrary/service"
+	"github.com/typical-go/typical-rest-server/pkg/dbtxn"
 	"github.com/typical-go/typical-rest-server/pkg/sqkit"
 )
 
+// primaryCtx match context which read from primary database (see dbtxn.ForcePrimary)
+type primaryCtx struct{}
+
+func (primaryCtx) Matches(x interface{}) bool {
+	ctx, ok := x.(context.Context)
+	primary, replica := &sql.DB{}, &sql.DB{}
+	return ok && dbtxn.Reader(ctx, primary, replica) == primary
+}
+
+func (primaryCtx) String() string { return "is context read from primary" }
+
 type bookSvcFn func(mockRepo *postgresdb_repo_mock.MockBookRepo)
 
 func createBookSvc(t *testing.T, fn bookSvcFn) (service.BookSvc, *gomock.Controller) {
@@ -77,7 +90,7 @@ func TestBookSvc_Create(t *testing.T) {
 					Create(gomock.Any(), &postgresdb.Book{Author: "some-author", Title: "some-title"}).
 					Return(int64(1), nil)
 				mockRepo.EXPECT().
-					Find(gomock.Any(), sqkit.Eq{"id": int64(1)}).
+					Find(primaryCtx{}, sqkit.Eq{"id": int64(1)}).
 					Return([]*postgresdb.Book{{Author: "some-author", Title: "some-title"}}, nil)
 			},
 		},
@@ -221,6 +234,7 @@ func TestBookSvc_Delete(t *testing.T) {
 		testName    string
 		bookSvcFn   bookSvcFn
 		paramID     string
+		version     int64
 		expectedErr string
 	}{
 		{
@@ -249,13 +263,34 @@ func TestBookSvc_Delete(t *testing.T) {
 					Return(int64(0), nil)
 			},
 		},
+		{
+			testName: "with version",
+			paramID:  "1",
+			version:  2,
+			bookSvcFn: func(mockRepo *postgresdb_repo_mock.MockBookRepo) {
+				mockRepo.EXPECT().
+					Delete(gomock.Any(), sqkit.Eq{postgresdb_repo.BookTable.ID: int64(1), postgresdb_repo.BookTable.Version: int64(2)}).
+					Return(int64(1), nil)
+			},
+		},
+		{
+			testName:    "version conflict",
+			paramID:     "1",
+			version:     2,
+			expectedErr: "sqkit: version conflict",
+			bookSvcFn: func(mockRepo *postgresdb_repo_mock.MockBookRepo) {
+				mockRepo.EXPECT().
+					Delete(gomock.Any(), sqkit.Eq{postgresdb_repo.BookTable.ID: int64(1), postgresdb_repo.BookTable.Version: int64(2)}).
+					Return(int64(0), nil)
+			},
+		},
 	}
 	for _, tt := range testcases {
 		t.Run(tt.testName, func(t *testing.T) {
 			svc, mock := createBookSvc(t, tt.bookSvcFn)
 			defer mock.Finish()
 
-			err := svc.Delete(context.Background(), tt.paramID)
+			err := svc.Delete(context.Background(), tt.paramID, tt.version)
 			if tt.expectedErr != "" {
 				require.EqualError(t, err, tt.expectedErr)
 			} else {
@@ -287,7 +322,7 @@ func TestBookSvc_Update(t *testing.T) {
 			expectedErr: "update error",
 			bookSvcFn: func(mockRepo *postgresdb_repo_mock.MockBookRepo) {
 				mockRepo.EXPECT().
-					Find(gomock.Any(), sqkit.Eq{"id": int64(1)}).
+					Find(primaryCtx{}, sqkit.Eq{"id": int64(1)}).
 					Return([]*postgresdb.Book{{ID: 1, Title: "some-title"}}, nil)
 				mockRepo.EXPECT().
 					Update(gomock.Any(), &postgresdb.Book{Author: "some-author", Title: "some-title"}, sqkit.Eq{"id": int64(1)}).
@@ -301,13 +336,27 @@ func TestBookSvc_Update(t *testing.T) {
 			expectedErr: "no affected row",
 			bookSvcFn: func(mockRepo *postgresdb_repo_mock.MockBookRepo) {
 				mockRepo.EXPECT().
-					Find(gomock.Any(), sqkit.Eq{"id": int64(1)}).
+					Find(primaryCtx{}, sqkit.Eq{"id": int64(1)}).
 					Return([]*postgresdb.Book{{ID: 1, Title: "some-title"}}, nil)
 				mockRepo.EXPECT().
 					Update(gomock.Any(), &postgresdb.Book{Author: "some-author", Title: "some-title"}, sqkit.Eq{"id": int64(1)}).
 					Return(int64(0), nil)
 			},
 		},
+		{
+			testName:    "version conflict",
+			paramID:     "1",
+			book:        &postgresdb.Book{Author: "some-author", Title: "some-title", Version: 1},
+			expectedErr: "sqkit: version conflict",
+			bookSvcFn: func(mockRepo *postgresdb_repo_mock.MockBookRepo) {
+				mockRepo.EXPECT().
+					Find(primaryCtx{}, sqkit.Eq{"id": int64(1)}).
+					Return([]*postgresdb.Book{{ID: 1, Title: "some-title", Version: 2}}, nil)
+				mockRepo.EXPECT().
+					Update(gomock.Any(), &postgresdb.Book{Author: "some-author", Title: "some-title", Version: 1}, sqkit.Eq{"id": int64(1)}).
+					Return(int64(0), sqkit.ErrVersionConflict)
+			},
+		},
 		{
 			testName:    "find error before update",
 			paramID:     "1",
@@ -315,7 +364,7 @@ func TestBookSvc_Update(t *testing.T) {
 			expectedErr: "find-error",
 			bookSvcFn: func(mockRepo *postgresdb_repo_mock.MockBookRepo) {
 				mockRepo.EXPECT().
-					Find(gomock.Any(), sqkit.Eq{"id": int64(1)}).
+					Find(primaryCtx{}, sqkit.Eq{"id": int64(1)}).
 					Return(nil, errors.New("find-error"))
 			},
 		},
@@ -326,13 +375,13 @@ func TestBookSvc_Update(t *testing.T) {
 			expectedErr: "find-error",
 			bookSvcFn: func(mockRepo *postgresdb_repo_mock.MockBookRepo) {
 				mockRepo.EXPECT().
-					Find(gomock.Any(), sqkit.Eq{"id": int64(1)}).
+					Find(primaryCtx{}, sqkit.Eq{"id": int64(1)}).
 					Return([]*postgresdb.Book{{ID: 1, Title: "some-title"}}, nil)
 				mockRepo.EXPECT().
 					Update(gomock.Any(), &postgresdb.Book{Author: "some-author", Title: "some-title"}, sqkit.Eq{"id": int64(1)}).
 					Return(int64(1), nil)
 				mockRepo.EXPECT().
-					Find(gomock.Any(), sqkit.Eq{"id": int64(1)}).
+					Find(primaryCtx{}, sqkit.Eq{"id": int64(1)}).
 					Return(nil, errors.New("find-error"))
 			},
 		},
@@ -359,6 +408,7 @@ func TestBookSvc_Patch(t *testing.T) {
 		bookSvcFn   bookSvcFn
 		paramID     string
 		book        *postgresdb.Book
+		columns     []string
 		expected    *postgresdb.Book
 		expectedErr string
 	}{
@@ -369,7 +419,7 @@ func TestBookSvc_Patch(t *testing.T) {
 			expectedErr: "patch-error",
 			bookSvcFn: func(mockRepo *postgresdb_repo_mock.MockBookRepo) {
 				mockRepo.EXPECT().
-					Find(gomock.Any(), sqkit.Eq{"id": int64(1)}).
+					Find(primaryCtx{}, sqkit.Eq{"id": int64(1)}).
 					Return([]*postgresdb.Book{{ID: 1, Title: "some-title"}}, nil)
 				mockRepo.EXPECT().
 					Patch(gomock.Any(), &postgresdb.Book{Author: "some-author", Title: "some-title"}, sqkit.Eq{"id": int64(1)}).
@@ -383,13 +433,27 @@ func TestBookSvc_Patch(t *testing.T) {
 			expectedErr: "no affected row",
 			bookSvcFn: func(mockRepo *postgresdb_repo_mock.MockBookRepo) {
 				mockRepo.EXPECT().
-					Find(gomock.Any(), sqkit.Eq{"id": int64(1)}).
+					Find(primaryCtx{}, sqkit.Eq{"id": int64(1)}).
 					Return([]*postgresdb.Book{{ID: 1, Title: "some-title"}}, nil)
 				mockRepo.EXPECT().
 					Patch(gomock.Any(), &postgresdb.Book{Author: "some-author", Title: "some-title"}, sqkit.Eq{"id": int64(1)}).
 					Return(int64(0), nil)
 			},
 		},
+		{
+			testName:    "version conflict",
+			paramID:     "1",
+			book:        &postgresdb.Book{Author: "some-author", Title: "some-title", Version: 1},
+			expectedErr: "sqkit: version conflict",
+			bookSvcFn: func(mockRepo *postgresdb_repo_mock.MockBookRepo) {
+				mockRepo.EXPECT().
+					Find(primaryCtx{}, sqkit.Eq{"id": int64(1)}).
+					Return([]*postgresdb.Book{{ID: 1, Title: "some-title", Version: 2}}, nil)
+				mockRepo.EXPECT().
+					Patch(gomock.Any(), &postgresdb.Book{Author: "some-author", Title: "some-title", Version: 1}, sqkit.Eq{"id": int64(1)}).
+					Return(int64(0), sqkit.ErrVersionConflict)
+			},
+		},
 		{
 			testName:    "find error before update",
 			paramID:     "1",
@@ -397,7 +461,7 @@ func TestBookSvc_Patch(t *testing.T) {
 			expectedErr: "find-error",
 			bookSvcFn: func(mockRepo *postgresdb_repo_mock.MockBookRepo) {
 				mockRepo.EXPECT().
-					Find(gomock.Any(), sqkit.Eq{"id": int64(1)}).
+					Find(primaryCtx{}, sqkit.Eq{"id": int64(1)}).
 					Return(nil, errors.New("find-error"))
 			},
 		},
@@ -408,13 +472,13 @@ func TestBookSvc_Patch(t *testing.T) {
 			expectedErr: "find-error",
 			bookSvcFn: func(mockRepo *postgresdb_repo_mock.MockBookRepo) {
 				mockRepo.EXPECT().
-					Find(gomock.Any(), sqkit.Eq{"id": int64(1)}).
+					Find(primaryCtx{}, sqkit.Eq{"id": int64(1)}).
 					Return([]*postgresdb.Book{{ID: 1, Title: "some-title"}}, nil)
 				mockRepo.EXPECT().
 					Patch(gomock.Any(), &postgresdb.Book{Author: "some-author", Title: "some-title"}, sqkit.Eq{"id": int64(1)}).
 					Return(int64(1), nil)
 				mockRepo.EXPECT().
-					Find(gomock.Any(), sqkit.Eq{"id": int64(1)}).
+					Find(primaryCtx{}, sqkit.Eq{"id": int64(1)}).
 					Return(nil, errors.New("find-error"))
 			},
 		},
@@ -424,22 +488,40 @@ func TestBookSvc_Patch(t *testing.T) {
 			expected: &postgresdb.Book{Author: "some-author", Title: "some-title"},
 			bookSvcFn: func(mockRepo *postgresdb_repo_mock.MockBookRepo) {
 				mockRepo.EXPECT().
-					Find(gomock.Any(), sqkit.Eq{"id": int64(1)}).
+					Find(primaryCtx{}, sqkit.Eq{"id": int64(1)}).
 					Return([]*postgresdb.Book{{ID: 1, Title: "some-title"}}, nil)
 				mockRepo.EXPECT().
 					Patch(gomock.Any(), &postgresdb.Book{Author: "some-author", Title: "some-title"}, sqkit.Eq{"id": int64(1)}).
 					Return(int64(1), nil)
 				mockRepo.EXPECT().
-					Find(gomock.Any(), sqkit.Eq{"id": int64(1)}).
+					Find(primaryCtx{}, sqkit.Eq{"id": int64(1)}).
 					Return([]*postgresdb.Book{{Author: "some-author", Title: "some-title"}}, nil)
 			},
 		},
+		{
+			testName: "patch with columns",
+			paramID:  "1",
+			book:     &postgresdb.Book{Title: "some-title"},
+			columns:  []string{"title", "author"},
+			expected: &postgresdb.Book{Title: "some-title"},
+			bookSvcFn: func(mockRepo *postgresdb_repo_mock.MockBookRepo) {
+				mockRepo.EXPECT().
+					Find(primaryCtx{}, sqkit.Eq{"id": int64(1)}).
+					Return([]*postgresdb.Book{{ID: 1, Title: "some-title", Author: "some-author"}}, nil)
+				mockRepo.EXPECT().
+					Patch(gomock.Any(), &postgresdb.Book{Title: "some-title"}, sqkit.Eq{"id": int64(1)}, "title", "author").
+					Return(int64(1), nil)
+				mockRepo.EXPECT().
+					Find(primaryCtx{}, sqkit.Eq{"id": int64(1)}).
+					Return([]*postgresdb.Book{{Title: "some-title"}}, nil)
+			},
+		},
 	}
 	for _, tt := range testcases {
 		t.Run(tt.testName, func(t *testing.T) {
 			svc, mock := createBookSvc(t, tt.bookSvcFn)
 			defer mock.Finish()
-			book, err := svc.Patch(context.Background(), tt.paramID, tt.book)
+			book, err := svc.Patch(context.Background(), tt.paramID, tt.book, tt.columns...)
 			if tt.expectedErr != "" {
 				require.EqualError(t, err, tt.expectedErr)
 			} else {
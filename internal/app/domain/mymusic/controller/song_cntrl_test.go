@@ -104,7 +104,7 @@ func TestSongCntrl_FindOne(t *testing.T) {
 					Target:    "/",
 					URLParams: map[string]string{"id": "2"},
 				},
-				ExpectedError: "code=500, message=some-error",
+				ExpectedError: "code=500, message=Internal Server Error, internal=some-error",
 			},
 			SongCntrlFn: func(svc *service_mock.MockSongSvc) {
 				svc.EXPECT().FindOne(gomock.Any(), "2").Return(nil, errors.New("some-error"))
@@ -156,7 +156,7 @@ func TestSongCntrl_Find(t *testing.T) {
 					Method: http.MethodGet,
 					Target: "/?limit=10&offset=20",
 				},
-				ExpectedError: "code=500, message=some-error",
+				ExpectedError: "code=500, message=Internal Server Error, internal=some-error",
 			},
 			SongCntrlFn: func(svc *service_mock.MockSongSvc) {
 				svc.EXPECT().
@@ -198,7 +198,7 @@ func TestSongCntrl_Update(t *testing.T) {
 					Header:    http.Header{"Content-Type": {"application/json"}},
 					Body:      `{"title":"some-title", "artist": "some-artist"}`,
 				},
-				ExpectedError: "code=500, message=some-error",
+				ExpectedError: "code=500, message=Internal Server Error, internal=some-error",
 			},
 			SongCntrlFn: func(svc *service_mock.MockSongSvc) {
 				svc.EXPECT().
@@ -263,7 +263,7 @@ func TestSongCntrl_Patch(t *testing.T) {
 					Header:    http.Header{"Content-Type": {"application/json"}},
 					Body:      `{"title":"some-title", "artist": "some-artist"}`,
 				},
-				ExpectedError: "code=500, message=some-error",
+				ExpectedError: "code=500, message=Internal Server Error, internal=some-error",
 			},
 			SongCntrlFn: func(svc *service_mock.MockSongSvc) {
 				svc.EXPECT().
@@ -330,7 +330,7 @@ func TestSongCntrl_Delete(t *testing.T) {
 					Target:    "/",
 					URLParams: map[string]string{"id": "1"},
 				},
-				ExpectedError: "code=500, message=some-error",
+				ExpectedError: "code=500, message=Internal Server Error, internal=some-error",
 			},
 			SongCntrlFn: func(svc *service_mock.MockSongSvc) {
 				svc.EXPECT().Delete(gomock.Any(), "1").Return(errors.New("some-error"))
@@ -381,7 +381,7 @@ func TestSongCntrl_Create(t *testing.T) {
 					Body:   `{"artist":"some-artist", "title":"some-title"}`,
 					Header: http.Header{"Content-Type": {"application/json"}},
 				},
-				ExpectedError: "code=500, message=some-error",
+				ExpectedError: "code=500, message=Internal Server Error, internal=some-error",
 			},
 			SongCntrlFn: func(svc *service_mock.MockSongSvc) {
 				svc.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("some-error"))
@@ -11,6 +11,7 @@ import (
 
 	"github.com/typical-go/typical-rest-server/internal/app/data_access/mysqldb"
 	"github.com/typical-go/typical-rest-server/internal/generated/mysqldb_repo"
+	"github.com/typical-go/typical-rest-server/pkg/dbtxn"
 	"github.com/typical-go/typical-rest-server/pkg/echokit"
 	"github.com/typical-go/typical-rest-server/pkg/sqkit"
 	"go.uber.org/dig"
@@ -61,7 +62,7 @@ func (b *SongSvcImpl) Create(ctx context.Context, book *mysqldb.Song) (*mysqldb.
 	if err != nil {
 		return nil, err
 	}
-	return b.findOne(ctx, id)
+	return b.findOne(dbtxn.ForcePrimary(ctx), id)
 }
 
 // Find books
@@ -111,6 +112,7 @@ func (b *SongSvcImpl) Delete(ctx context.Context, paramID string) error {
 
 // Update book
 func (b *SongSvcImpl) Update(ctx context.Context, paramID string, book *mysqldb.Song) (*mysqldb.Song, error) {
+	ctx = dbtxn.ForcePrimary(ctx)
 	id, _ := strconv.ParseInt(paramID, 10, 64)
 
 	if err := validator.New().Struct(book); err != nil {
@@ -131,13 +133,14 @@ func (b *SongSvcImpl) update(ctx context.Context, id int64, song *mysqldb.Song)
 		return err
 	}
 	if affectedRow < 1 {
-		return errors.New("no affected row")
+		return echokit.Wrap(echokit.ErrNotFound, errors.New("no affected row"))
 	}
 	return nil
 }
 
 // Patch book
 func (b *SongSvcImpl) Patch(ctx context.Context, paramID string, song *mysqldb.Song) (*mysqldb.Song, error) {
+	ctx = dbtxn.ForcePrimary(ctx)
 	id, _ := strconv.ParseInt(paramID, 10, 64)
 
 	if _, err := b.findOne(ctx, id); err != nil {
@@ -155,7 +158,7 @@ func (b *SongSvcImpl) patch(ctx context.Context, id int64, song *mysqldb.Song) e
 		return err
 	}
 	if affectedRow < 1 {
-		return errors.New("no affected row")
+		return echokit.Wrap(echokit.ErrNotFound, errors.New("no affected row"))
 	}
 	return nil
 }
@@ -18,9 +18,11 @@ type (
 	// HealthCheck ...
 	HealthCheck struct {
 		dig.In
-		PG    *sql.DB `name:"pg"`
-		MySQL *sql.DB `name:"mysql"`
-		Cache *cachekit.Store
+		PG           *sql.DB `name:"pg"`
+		PGReplica    *sql.DB `name:"pg_replica"`
+		MySQL        *sql.DB `name:"mysql"`
+		MySQLReplica *sql.DB `name:"mysql_replica"`
+		Cache        *cachekit.Store
 	}
 )
 
@@ -30,9 +32,19 @@ func (h *HealthCheck) Handle(ec echo.Context) error {
 	defer cancel()
 
 	health := typrest.HealthMap{
-		"postgres": h.PG.Ping(),
-		"mysql":    h.MySQL.Ping(),
-		"cache":    h.Cache.Ping(ctx).Err(),
+		"cache": h.Cache.Ping(ctx).Err(),
+	}
+	if h.PG != nil { // optional when sqlite is selected
+		health["postgres"] = h.PG.Ping()
+	}
+	if h.MySQL != nil {
+		health["mysql"] = h.MySQL.Ping()
+	}
+	if h.PGReplica != nil {
+		health["postgres_replica"] = h.PGReplica.Ping()
+	}
+	if h.MySQLReplica != nil {
+		health["mysql_replica"] = h.MySQLReplica.Ping()
 	}
 
 	status, ok := health.Status()
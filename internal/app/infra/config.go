@@ -10,33 +10,42 @@ type (
 	// AppCfg application configuration
 	// @envconfig (prefix:"APP")
 	AppCfg struct {
-		Address      string        `envconfig:"ADDRESS" default:":8089" required:"true"`
-		ReadTimeout  time.Duration `envconfig:"READ_TIMEOUT" default:"5s"`
-		WriteTimeout time.Duration `envconfig:"WRITE_TIMEOUT" default:"10s"`
-		Debug        bool          `envconfig:"DEBUG" default:"true"`
+		Address      string        `envconfig:"ADDRESS" default:":8089" required:"true" validate:"hostport"` // server address e.g. `:8089` or `localhost:8089`
+		ReadTimeout  time.Duration `envconfig:"READ_TIMEOUT" default:"5s" validate:"gt=0"`                   // maximum duration to read the request
+		WriteTimeout time.Duration `envconfig:"WRITE_TIMEOUT" default:"10s" validate:"gt=0"`                 // maximum duration to write the response
+		Debug        bool          `envconfig:"DEBUG" default:"true" reload:"true"`                          // debug mode of echo server and log level
 	}
 	// CacheCfg cache onfiguration
 	// @envconfig (prefix:"CACHE")
 	CacheCfg struct {
-		DefaultMaxAge time.Duration `envconfig:"DEFAULT_MAX_AGE" default:"30s"`
-		PrefixKey     string        `envconfig:"PREFIX_KEY" default:"cache_"`
-		RedisHost     string        `envconfig:"REDIS_HOST" required:"true" default:"localhost"`
-		RedisPort     string        `envconfig:"REDIS_PORT" required:"true" default:"6379"`
-		RedisPass     string        `envconfig:"REDIS_PASS" default:"redispass"`
+		DefaultMaxAge time.Duration `envconfig:"DEFAULT_MAX_AGE" default:"30s" validate:"gte=0" reload:"true"` // max-age of cache if not set by request
+		PrefixKey     string        `envconfig:"PREFIX_KEY" default:"cache_"`                                  // prefix of redis key
+		RedisHost     string        `envconfig:"REDIS_HOST" required:"true" default:"localhost"`               // redis host
+		RedisPort     string        `envconfig:"REDIS_PORT" required:"true" default:"6379" validate:"numeric"` // redis port
+		RedisPass     string        `envconfig:"REDIS_PASS" default:"redispass" secret:"true"`                 // redis password
 	}
 	// DatabaseCfg is MySQL configuration
 	// @envconfig (prefix:"MYSQL" ctor:"mysql")
 	// @envconfig (prefix:"PG" ctor:"pg")
 	DatabaseCfg struct {
-		DBName string `envconfig:"DBNAME" required:"true" default:"dbname"`
-		DBUser string `envconfig:"DBUSER" required:"true" default:"dbuser"`
-		DBPass string `envconfig:"DBPASS" required:"true" default:"dbpass"`
-		Host   string `envconfig:"HOST" required:"true" default:"localhost"`
-		Port   string `envconfig:"PORT" required:"true" default:"9999"`
-
-		MaxOpenConns    int           `envconfig:"MAX_OPEN_CONNS" default:"30" required:"true"`
-		MaxIdleConns    int           `envconfig:"MAX_IDLE_CONNS" default:"6" required:"true"`
-		ConnMaxLifetime time.Duration `envconfig:"CONN_MAX_LIFETIME" default:"30m" required:"true"`
+		DBName string `envconfig:"DBNAME" required:"true" default:"dbname"`                // database name
+		DBUser string `envconfig:"DBUSER" required:"true" default:"dbuser"`                // database user
+		DBPass string `envconfig:"DBPASS" required:"true" default:"dbpass" secret:"true"`  // database password
+		Host   string `envconfig:"HOST" required:"true" default:"localhost"`               // database host
+		Port   string `envconfig:"PORT" required:"true" default:"9999" validate:"numeric"` // database port
+
+		ReplicaHost string `envconfig:"REPLICA_HOST"`                              // optional host of read replica
+		ReplicaPort string `envconfig:"REPLICA_PORT" validate:"omitempty,numeric"` // by default is same with PORT
+
+		MaxOpenConns    int           `envconfig:"MAX_OPEN_CONNS" default:"30" required:"true" validate:"min=1"`                      // maximum number of open connections
+		MaxIdleConns    int           `envconfig:"MAX_IDLE_CONNS" default:"6" required:"true" validate:"min=0,ltefield=MaxOpenConns"` // maximum number of idle connections
+		ConnMaxLifetime time.Duration `envconfig:"CONN_MAX_LIFETIME" default:"30m" required:"true" validate:"gte=0"`                  // maximum amount of time a connection may be reused
+	}
+	// SqliteCfg is SQLite configuration
+	// @envconfig (prefix:"SQLITE")
+	SqliteCfg struct {
+		DBName       string `envconfig:"DBNAME"`                                                      // optional sqlite database file e.g. `sqlite.db`, sqlite is disabled if empty
+		MaxOpenConns int    `envconfig:"MAX_OPEN_CONNS" default:"1" required:"true" validate:"min=1"` // maximum number of open connections
 	}
 )
 
@@ -56,3 +65,27 @@ func (p *DatabaseCfg) Config() *dbtool.Config {
 		Port:   p.Port,
 	}
 }
+
+// Replica return configuration of read replica or nil if not available
+func (p *DatabaseCfg) Replica() *DatabaseCfg {
+	if p.ReplicaHost == "" {
+		return nil
+	}
+	replica := *p
+	replica.Host = p.ReplicaHost
+	if p.ReplicaPort != "" {
+		replica.Port = p.ReplicaPort
+	}
+	return &replica
+}
+
+//
+// SqliteCfg
+//
+
+var _ dbtool.Configurer = (*SqliteCfg)(nil)
+
+// Config for sqlitetool
+func (s *SqliteCfg) Config() *dbtool.Config {
+	return &dbtool.Config{DBName: s.DBName}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/sirupsen/logrus"
+	"github.com/typical-go/typical-rest-server/pkg/echokit/sqliteerr"
 	"go.uber.org/dig"
 
 	// postgres driver
@@ -12,54 +13,103 @@ import (
 
 	// mysql driver
 	_ "github.com/go-sql-driver/mysql"
+
+	// sqlite driver
+	_ "github.com/mattn/go-sqlite3"
 )
 
 type (
 	dbConfigs struct {
 		dig.In
-		PgCfg    *DatabaseCfg `name:"pg"`
-		MysqlCfg *DatabaseCfg `name:"mysql"`
+		PgCfg     *DatabaseCfg `name:"pg"`
+		MysqlCfg  *DatabaseCfg `name:"mysql"`
+		SqliteCfg *SqliteCfg
 	}
 	// Databases setup output
 	Databases struct {
 		dig.Out
-		Pg    *sql.DB `name:"pg"`
-		MySQL *sql.DB `name:"mysql"`
+		Pg           *sql.DB `name:"pg"`
+		PgReplica    *sql.DB `name:"pg_replica"`
+		MySQL        *sql.DB `name:"mysql"`
+		MySQLReplica *sql.DB `name:"mysql_replica"`
+		Sqlite       *sql.DB `name:"sqlite"`
 	}
 )
 
-// NewDatabases return new instance of databases
+// NewDatabases return new instance of databases. Postgres and mysql are optional (nil if failed to connect)
+// when sqlite is selected, otherwise the application exit if failed to connect
 // @ctor
 func NewDatabases(c dbConfigs) Databases {
+	sqlite := createSqliteConn(c.SqliteCfg)
+	optional := sqlite != nil
+	if sqlite != nil {
+		sqliteerr.Register()
+	}
 	return Databases{
-		Pg:    createPGConn(c.PgCfg),
-		MySQL: createMySQLConn(c.MysqlCfg),
+		Pg:           connect("postgres", c.PgCfg, createPGConn, optional),
+		PgReplica:    connect("postgres_replica", c.PgCfg.Replica(), createPGConn, optional),
+		MySQL:        connect("mysql", c.MysqlCfg, createMySQLConn, optional),
+		MySQLReplica: connect("mysql_replica", c.MysqlCfg.Replica(), createMySQLConn, optional),
+		Sqlite:       sqlite,
 	}
 }
 
-func createMySQLConn(p *DatabaseCfg) *sql.DB {
+// connect return nil if the config is nil (e.g. replica is not configured) or if failed to connect the optional database
+func connect(name string, p *DatabaseCfg, createConn func(*DatabaseCfg) (*sql.DB, error), optional bool) *sql.DB {
+	if p == nil {
+		return nil
+	}
+	db, err := createConn(p)
+	if err == nil {
+		return db
+	}
+	if !optional {
+		logrus.Fatalf("%s: %s", name, err.Error())
+	}
+	logrus.Warnf("%s: %s", name, err.Error())
+	return nil
+}
+
+// createSqliteConn return nil if the database file is not configured
+func createSqliteConn(s *SqliteCfg) *sql.DB {
+	if s.DBName == "" {
+		return nil
+	}
+	db, err := sql.Open("sqlite3", s.DBName)
+	if err != nil {
+		logrus.Fatalf("sqlite: %s", err.Error())
+	}
+	db.SetMaxOpenConns(s.MaxOpenConns) // sqlite allow only one writer at a time
+	if err = db.Ping(); err != nil {
+		logrus.Fatalf("sqlite: %s", err.Error())
+	}
+	return db
+}
+
+func createMySQLConn(p *DatabaseCfg) (*sql.DB, error) {
 	db, err := sql.Open("mysql", fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?tls=false&parseTime=true",
 		p.DBUser, p.DBPass, p.Host, p.Port, p.DBName))
 	if err != nil {
-		logrus.Fatalf("msyql: %s", err.Error())
+		return nil, err
 	}
 	db.SetConnMaxLifetime(p.ConnMaxLifetime)
 	db.SetMaxIdleConns(p.MaxIdleConns)
 	db.SetMaxOpenConns(p.MaxOpenConns)
 	if err = db.Ping(); err != nil {
-		logrus.Fatalf("msyql: %s", err.Error())
+		db.Close()
+		return nil, err
 	}
-	return db
+	return db, nil
 }
 
-func createPGConn(p *DatabaseCfg) *sql.DB {
+func createPGConn(p *DatabaseCfg) (*sql.DB, error) {
 	conn := fmt.Sprintf(
 		"postgres://%s:%s@%s:%s/%s?sslmode=disable",
 		p.DBUser, p.DBPass, p.Host, p.Port, p.DBName,
 	)
 	db, err := sql.Open("postgres", conn)
 	if err != nil {
-		logrus.Fatalf("postgres: %s", err.Error())
+		return nil, err
 	}
 
 	db.SetConnMaxLifetime(p.ConnMaxLifetime)
@@ -67,8 +117,9 @@ func createPGConn(p *DatabaseCfg) *sql.DB {
 	db.SetMaxOpenConns(p.MaxOpenConns)
 
 	if err = db.Ping(); err != nil {
-		logrus.Fatalf("postgres: %s", err.Error())
+		db.Close()
+		return nil, err
 	}
 
-	return db
+	return db, nil
 }
@@ -0,0 +1,41 @@
+package infra_test
+
+import (
+	"database/sql"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/typical-go/typical-rest-server/internal/app/infra"
+	"go.uber.org/dig"
+)
+
+func TestNewDatabases_SqliteOnly(t *testing.T) {
+	dir, err := ioutil.TempDir("", "infra")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	unreachable := &infra.DatabaseCfg{Host: "localhost", Port: "1", MaxOpenConns: 1}
+	di := dig.New()
+	require.NoError(t, di.Provide(func() *infra.DatabaseCfg { return unreachable }, dig.Name("pg")))
+	require.NoError(t, di.Provide(func() *infra.DatabaseCfg { return unreachable }, dig.Name("mysql")))
+	require.NoError(t, di.Provide(func() *infra.SqliteCfg {
+		return &infra.SqliteCfg{DBName: filepath.Join(dir, "test.db"), MaxOpenConns: 1}
+	}))
+	require.NoError(t, di.Provide(infra.NewDatabases))
+
+	require.NoError(t, di.Invoke(func(dbs struct {
+		dig.In
+		Pg     *sql.DB `name:"pg"`
+		MySQL  *sql.DB `name:"mysql"`
+		Sqlite *sql.DB `name:"sqlite"`
+	}) {
+		require.Nil(t, dbs.Pg)
+		require.Nil(t, dbs.MySQL)
+		require.NotNil(t, dbs.Sqlite)
+		require.NoError(t, dbs.Sqlite.Ping())
+		dbs.Sqlite.Close()
+	}))
+}
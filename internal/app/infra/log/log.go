@@ -3,16 +3,28 @@ package log
 import (
 	"context"
 	"log"
+	"sync/atomic"
 
 	"github.com/sirupsen/logrus"
 	"github.com/typical-go/typical-rest-server/pkg/logruskit"
 )
 
-var _debug bool
+var _debug int32
 
-// SetDebug set logger
+// SetDebug set logger and redirect standard log to it. Use UpdateDebug to change debug mode afterward
 func SetDebug(debug bool) *logrus.Logger {
-	_debug = debug
+	logger := UpdateDebug(debug)
+	log.SetOutput(logger.Writer())
+	return logger
+}
+
+// UpdateDebug change debug mode of the logger. It is safe to call while serving e.g. on config reload
+func UpdateDebug(debug bool) *logrus.Logger {
+	var v int32
+	if debug {
+		v = 1
+	}
+	atomic.StoreInt32(&_debug, v)
 	logger := logrus.StandardLogger()
 	if debug {
 		logger.SetLevel(logrus.DebugLevel)
@@ -21,10 +33,14 @@ func SetDebug(debug bool) *logrus.Logger {
 		logger.SetLevel(logrus.WarnLevel)
 		logger.SetFormatter(&logrus.JSONFormatter{})
 	}
-	log.SetOutput(logger.Writer())
 	return logger
 }
 
+// IsDebug return true in debug mode
+func IsDebug() bool {
+	return atomic.LoadInt32(&_debug) == 1
+}
+
 // Info ..
 func Info(ctx context.Context, args ...interface{}) {
 	logrus.WithFields(logruskit.GetFields(ctx)).Info(args...)
@@ -38,7 +38,7 @@ func Middleware(next echo.HandlerFunc) echo.HandlerFunc {
 		}
 
 		stop := time.Now()
-		if _debug {
+		if IsDebug() {
 			logrus.WithFields(logrus.Fields{
 				"exec_time":   stop.Sub(start).String(),
 				"req_id":      reqID,
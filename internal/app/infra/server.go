@@ -3,6 +3,7 @@ package infra
 import (
 	"github.com/labstack/echo/v4"
 	"github.com/typical-go/typical-rest-server/internal/app/infra/log"
+	"github.com/typical-go/typical-rest-server/pkg/echokit"
 	"github.com/typical-go/typical-rest-server/pkg/logruskit"
 )
 
@@ -15,5 +16,6 @@ func NewServer(cfg *AppCfg) *echo.Echo {
 	e.HideBanner = true
 	e.Debug = cfg.Debug
 	e.Logger = logruskit.EchoLogger(logger)
+	e.HTTPErrorHandler = echokit.NewProblemErrorHandler(log.IsDebug)
 	return e
 }
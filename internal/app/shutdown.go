@@ -14,10 +14,13 @@ import (
 type (
 	shutdown struct {
 		dig.In
-		Pg    *sql.DB `name:"pg"`
-		MySQL *sql.DB `name:"mysql"`
-		Cache *cachekit.Store
-		Echo  *echo.Echo
+		Pg           *sql.DB `name:"pg"`
+		PgReplica    *sql.DB `name:"pg_replica"`
+		MySQL        *sql.DB `name:"mysql"`
+		MySQLReplica *sql.DB `name:"mysql_replica"`
+		Sqlite       *sql.DB `name:"sqlite"`
+		Cache        *cachekit.Store
+		Echo         *echo.Echo
 	}
 )
 
@@ -27,11 +30,21 @@ func Shutdown(p shutdown) error {
 	defer cancel()
 
 	errs := errkit.Errors{
-		p.Pg.Close(),
-		p.MySQL.Close(),
+		closeOptional(p.Pg),
+		closeOptional(p.PgReplica),
+		closeOptional(p.MySQL),
+		closeOptional(p.MySQLReplica),
+		closeOptional(p.Sqlite),
 		p.Cache.Close(),
 		p.Echo.Shutdown(ctx),
 	}
 
 	return errs.Unwrap()
 }
+
+func closeOptional(db *sql.DB) error {
+	if db == nil {
+		return nil
+	}
+	return db.Close()
+}
@@ -3,6 +3,7 @@ package app
 import (
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
@@ -12,7 +13,10 @@ import (
 	"github.com/typical-go/typical-rest-server/internal/app/domain/mymusic"
 	"github.com/typical-go/typical-rest-server/internal/app/infra"
 	"github.com/typical-go/typical-rest-server/internal/app/infra/log"
+	"github.com/typical-go/typical-rest-server/internal/generated/config"
+	"github.com/typical-go/typical-rest-server/pkg/cachekit"
 	"github.com/typical-go/typical-rest-server/pkg/echokit"
+	"github.com/typical-go/typical-rest-server/pkg/typcfg"
 	"go.uber.org/dig"
 
 	// enable `/debug/vars`
@@ -24,6 +28,8 @@ import (
 
 const (
 	healthCheckPath = "/application/health"
+	configPath      = "/debug/config" // effective config and its source (secret is redacted)
+	reloadInterval  = 5 * time.Second // interval to check dotenv modification for config reload
 )
 
 type (
@@ -31,6 +37,7 @@ type (
 		dig.In
 		*echo.Echo
 		Config      *infra.AppCfg
+		Cache       *cachekit.Store
 		MyLibrary   mylibrary.Router
 		MyMusic     mymusic.Router
 		HealthCheck HealthCheck
@@ -42,6 +49,8 @@ func Start(a app) (err error) {
 	setMiddleware(a)
 	setRoute(a)
 	setProfiler(a)
+	setReload(a)
+	defer typcfg.Watch(reloadInterval)()
 
 	if a.Config.Debug {
 		routes := echokit.DumpEcho(a.Echo)
@@ -67,9 +76,19 @@ func setRoute(a app) {
 	)
 }
 
+func setReload(a app) {
+	config.OnAppCfgReload(func(cfg *infra.AppCfg) {
+		log.UpdateDebug(cfg.Debug)
+	})
+	config.OnCacheCfgReload(func(cfg *infra.CacheCfg) {
+		a.Cache.SetDefaultMaxAge(cfg.DefaultMaxAge)
+	})
+}
+
 func setProfiler(a app) {
 	a.GET(healthCheckPath, a.HealthCheck.Handle)
 	a.HEAD(healthCheckPath, a.HealthCheck.Handle)
+	a.GET(configPath, echo.WrapHandler(typcfg.DumpHandler()))
 	a.GET("/debug/*", echo.WrapHandler(http.DefaultServeMux))
 	a.GET("/debug/*/*", echo.WrapHandler(http.DefaultServeMux))
 }
@@ -5,9 +5,9 @@ package config
 import (
 	"fmt"
 
-	"github.com/kelseyhightower/envconfig"
 	"github.com/typical-go/typical-go/pkg/typapp"
 	a "github.com/typical-go/typical-rest-server/internal/app/infra"
+	"github.com/typical-go/typical-rest-server/pkg/typcfg"
 )
 
 func init() {
@@ -15,33 +15,50 @@ func init() {
 	typapp.Provide("", LoadCacheCfg)
 	typapp.Provide("mysql", LoadMysqlDatabaseCfg)
 	typapp.Provide("pg", LoadPgDatabaseCfg)
+	typapp.Provide("", LoadSqliteCfg)
 }
 
 // LoadAppCfg load env to new instance of AppCfg
 func LoadAppCfg() (*a.AppCfg, error) {
 	var cfg a.AppCfg
 	prefix := "APP"
-	if err := envconfig.Process(prefix, &cfg); err != nil {
+	if err := typcfg.Process(prefix, &cfg); err != nil {
 		return nil, fmt.Errorf("%s: %w", prefix, err)
 	}
 	return &cfg, nil
 }
 
+// OnAppCfgReload register callback when reloadable field of AppCfg changed
+func OnAppCfgReload(fn func(*a.AppCfg)) {
+	typcfg.OnReload("APP",
+		func() (interface{}, error) { return LoadAppCfg() },
+		func(cfg interface{}) { fn(cfg.(*a.AppCfg)) },
+	)
+}
+
 // LoadCacheCfg load env to new instance of CacheCfg
 func LoadCacheCfg() (*a.CacheCfg, error) {
 	var cfg a.CacheCfg
 	prefix := "CACHE"
-	if err := envconfig.Process(prefix, &cfg); err != nil {
+	if err := typcfg.Process(prefix, &cfg); err != nil {
 		return nil, fmt.Errorf("%s: %w", prefix, err)
 	}
 	return &cfg, nil
 }
 
+// OnCacheCfgReload register callback when reloadable field of CacheCfg changed
+func OnCacheCfgReload(fn func(*a.CacheCfg)) {
+	typcfg.OnReload("CACHE",
+		func() (interface{}, error) { return LoadCacheCfg() },
+		func(cfg interface{}) { fn(cfg.(*a.CacheCfg)) },
+	)
+}
+
 // LoadMysqlDatabaseCfg load env to new instance of DatabaseCfg
 func LoadMysqlDatabaseCfg() (*a.DatabaseCfg, error) {
 	var cfg a.DatabaseCfg
 	prefix := "MYSQL"
-	if err := envconfig.Process(prefix, &cfg); err != nil {
+	if err := typcfg.Process(prefix, &cfg); err != nil {
 		return nil, fmt.Errorf("%s: %w", prefix, err)
 	}
 	return &cfg, nil
@@ -51,7 +68,17 @@ func LoadMysqlDatabaseCfg() (*a.DatabaseCfg, error) {
 func LoadPgDatabaseCfg() (*a.DatabaseCfg, error) {
 	var cfg a.DatabaseCfg
 	prefix := "PG"
-	if err := envconfig.Process(prefix, &cfg); err != nil {
+	if err := typcfg.Process(prefix, &cfg); err != nil {
+		return nil, fmt.Errorf("%s: %w", prefix, err)
+	}
+	return &cfg, nil
+}
+
+// LoadSqliteCfg load env to new instance of SqliteCfg
+func LoadSqliteCfg() (*a.SqliteCfg, error) {
+	var cfg a.SqliteCfg
+	prefix := "SQLITE"
+	if err := typcfg.Process(prefix, &cfg); err != nil {
 		return nil, fmt.Errorf("%s: %w", prefix, err)
 	}
 	return &cfg, nil
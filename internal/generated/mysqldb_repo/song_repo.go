@@ -3,13 +3,13 @@ package mysqldb_repo
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"time"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/typical-go/typical-go/pkg/typapp"
 	"github.com/typical-go/typical-rest-server/internal/app/data_access/mysqldb"
 	"github.com/typical-go/typical-rest-server/pkg/dbtxn"
-	"github.com/typical-go/typical-rest-server/pkg/reflectkit"
 	"github.com/typical-go/typical-rest-server/pkg/sqkit"
 	"go.uber.org/dig"
 )
@@ -39,15 +39,19 @@ type (
 	SongRepo interface {
 		Count(context.Context, ...sqkit.SelectOption) (int64, error)
 		Find(context.Context, ...sqkit.SelectOption) ([]*mysqldb.Song, error)
+		Each(context.Context, func(*mysqldb.Song) error, ...sqkit.SelectOption) error
+		FindByPK(context.Context, int64) (*mysqldb.Song, error)
+		DeleteByPK(context.Context, int64) (int64, error)
 		Create(context.Context, *mysqldb.Song) (int64, error)
 		Delete(context.Context, sqkit.DeleteOption) (int64, error)
 		Update(context.Context, *mysqldb.Song, sqkit.UpdateOption) (int64, error)
-		Patch(context.Context, *mysqldb.Song, sqkit.UpdateOption) (int64, error)
+		Patch(context.Context, *mysqldb.Song, sqkit.UpdateOption, ...string) (int64, error)
 	}
 	// SongRepoImpl is implementation songs repository
 	SongRepoImpl struct {
 		dig.In
 		*sql.DB `name:"mysql"`
+		Replica *sql.DB `name:"mysql_replica" optional:"true"`
 	}
 )
 
@@ -55,9 +59,19 @@ func init() {
 	typapp.Provide("", NewSongRepo)
 }
 
-// NewSongRepo return new instance of SongRepo
-func NewSongRepo(impl SongRepoImpl) SongRepo {
-	return &impl
+// NewSongRepo return new instance of SongRepo or error if the database is not available
+func NewSongRepo(impl SongRepoImpl) (SongRepo, error) {
+	if impl.DB == nil {
+		return nil, errors.New("SongRepo: missing database")
+	}
+	return &impl, nil
+}
+
+// SongPK return condition of songs primary key
+func SongPK(id int64) sqkit.Eq {
+	return sqkit.Eq{
+		SongTable.ID: id,
+	}
 }
 
 // Count songs
@@ -65,7 +79,7 @@ func (r *SongRepoImpl) Count(ctx context.Context, opts ...sqkit.SelectOption) (i
 	builder := sq.
 		Select("count(*)").
 		From(SongTableName).
-		RunWith(r)
+		RunWith(dbtxn.Reader(ctx, r.DB, r.Replica))
 
 	for _, opt := range opts {
 		builder = opt.CompileSelect(builder)
@@ -81,7 +95,19 @@ func (r *SongRepoImpl) Count(ctx context.Context, opts ...sqkit.SelectOption) (i
 }
 
 // Find songs
-func (r *SongRepoImpl) Find(ctx context.Context, opts ...sqkit.SelectOption) (list []*mysqldb.Song, err error) {
+func (r *SongRepoImpl) Find(ctx context.Context, opts ...sqkit.SelectOption) ([]*mysqldb.Song, error) {
+	list := make([]*mysqldb.Song, 0)
+	if err := r.Each(ctx, func(ent *mysqldb.Song) error {
+		list = append(list, ent)
+		return nil
+	}, opts...); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// Each iterate songs row by row without load all rows to memory. The iteration stop when fn return error or context is done
+func (r *SongRepoImpl) Each(ctx context.Context, fn func(*mysqldb.Song) error, opts ...sqkit.SelectOption) error {
 	builder := sq.
 		Select(
 			SongTable.ID,
@@ -91,7 +117,7 @@ func (r *SongRepoImpl) Find(ctx context.Context, opts ...sqkit.SelectOption) (li
 			SongTable.CreatedAt,
 		).
 		From(SongTableName).
-		RunWith(r)
+		RunWith(dbtxn.Reader(ctx, r.DB, r.Replica))
 
 	for _, opt := range opts {
 		builder = opt.CompileSelect(builder)
@@ -99,24 +125,46 @@ func (r *SongRepoImpl) Find(ctx context.Context, opts ...sqkit.SelectOption) (li
 
 	rows, err := builder.QueryContext(ctx)
 	if err != nil {
-		return
+		return err
 	}
+	defer rows.Close()
 
-	list = make([]*mysqldb.Song, 0)
 	for rows.Next() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 		ent := new(mysqldb.Song)
-		if err = rows.Scan(
+		if err := rows.Scan(
 			&ent.ID,
 			&ent.Title,
 			&ent.Artist,
 			&ent.UpdatedAt,
 			&ent.CreatedAt,
 		); err != nil {
-			return
+			return err
+		}
+		if err := fn(ent); err != nil {
+			return err
 		}
-		list = append(list, ent)
 	}
-	return
+	return rows.Err()
+}
+
+// FindByPK find songs by primary key. Return sql.ErrNoRows if not found
+func (r *SongRepoImpl) FindByPK(ctx context.Context, id int64) (*mysqldb.Song, error) {
+	list, err := r.Find(ctx, SongPK(id))
+	if err != nil {
+		return nil, err
+	}
+	if len(list) < 1 {
+		return nil, sql.ErrNoRows
+	}
+	return list[0], nil
+}
+
+// DeleteByPK delete songs by primary key
+func (r *SongRepoImpl) DeleteByPK(ctx context.Context, id int64) (int64, error) {
+	return r.Delete(ctx, SongPK(id))
 }
 
 // Create songs
@@ -126,6 +174,11 @@ func (r *SongRepoImpl) Create(ctx context.Context, ent *mysqldb.Song) (int64, er
 		return -1, err
 	}
 
+	if err := sqkit.BeforeCreate(ctx, ent); err != nil {
+		txn.SetError(err)
+		return -1, err
+	}
+
 	res, err := sq.
 		Insert(SongTableName).
 		Columns(
@@ -148,9 +201,13 @@ func (r *SongRepoImpl) Create(ctx context.Context, ent *mysqldb.Song) (int64, er
 		return -1, err
 	}
 
-	lastInsertID, err := res.LastInsertId()
+	id, err := res.LastInsertId()
+	if err == nil {
+		ent.ID = id
+		err = sqkit.AfterCreate(ctx, ent)
+	}
 	txn.SetError(err)
-	return lastInsertID, err
+	return id, err
 }
 
 // Update songs
@@ -160,6 +217,11 @@ func (r *SongRepoImpl) Update(ctx context.Context, ent *mysqldb.Song, opt sqkit.
 		return -1, err
 	}
 
+	if err := sqkit.BeforeUpdate(ctx, ent); err != nil {
+		txn.SetError(err)
+		return -1, err
+	}
+
 	builder := sq.
 		Update(SongTableName).
 		Set(SongTable.Title, ent.Title).
@@ -177,23 +239,31 @@ func (r *SongRepoImpl) Update(ctx context.Context, ent *mysqldb.Song, opt sqkit.
 		return -1, err
 	}
 	affectedRow, err := res.RowsAffected()
+	if err == nil {
+		err = sqkit.AfterUpdate(ctx, ent)
+	}
 	txn.SetError(err)
 	return affectedRow, err
 }
 
 // Patch songs
-func (r *SongRepoImpl) Patch(ctx context.Context, ent *mysqldb.Song, opt sqkit.UpdateOption) (int64, error) {
+func (r *SongRepoImpl) Patch(ctx context.Context, ent *mysqldb.Song, opt sqkit.UpdateOption, columns ...string) (int64, error) {
 	txn, err := dbtxn.Use(ctx, r.DB)
 	if err != nil {
 		return -1, err
 	}
 
+	if err := sqkit.BeforePatch(ctx, ent, columns); err != nil {
+		txn.SetError(err)
+		return -1, err
+	}
+
 	builder := sq.Update(SongTableName).RunWith(txn.DB)
 
-	if !reflectkit.IsZero(ent.Title) {
+	if sqkit.ShouldPatch(columns, SongTable.Title, ent.Title) {
 		builder = builder.Set(SongTable.Title, ent.Title)
 	}
-	if !reflectkit.IsZero(ent.Artist) {
+	if sqkit.ShouldPatch(columns, SongTable.Artist, ent.Artist) {
 		builder = builder.Set(SongTable.Artist, ent.Artist)
 	}
 	builder = builder.Set(SongTable.UpdatedAt, time.Now())
@@ -209,6 +279,9 @@ func (r *SongRepoImpl) Patch(ctx context.Context, ent *mysqldb.Song, opt sqkit.U
 	}
 
 	affectedRow, err := res.RowsAffected()
+	if err == nil {
+		err = sqkit.AfterPatch(ctx, ent, columns)
+	}
 	txn.SetError(err)
 	return affectedRow, err
 }
@@ -220,6 +293,11 @@ func (r *SongRepoImpl) Delete(ctx context.Context, opt sqkit.DeleteOption) (int6
 		return -1, err
 	}
 
+	if err := sqkit.BeforeDelete(ctx, (*mysqldb.Song)(nil), opt); err != nil {
+		txn.SetError(err)
+		return -1, err
+	}
+
 	builder := sq.Delete(SongTableName).RunWith(txn.DB)
 	if opt != nil {
 		builder = opt.CompileDelete(builder)
@@ -232,6 +310,9 @@ func (r *SongRepoImpl) Delete(ctx context.Context, opt sqkit.DeleteOption) (int6
 	}
 
 	affectedRow, err := res.RowsAffected()
+	if err == nil {
+		err = sqkit.AfterDelete(ctx, (*mysqldb.Song)(nil), opt)
+	}
 	txn.SetError(err)
 	return affectedRow, err
 }
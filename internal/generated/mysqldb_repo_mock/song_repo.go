@@ -85,6 +85,40 @@ func (mr *MockSongRepoMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSongRepo)(nil).Delete), arg0, arg1)
 }
 
+// DeleteByPK mocks base method
+func (m *MockSongRepo) DeleteByPK(arg0 context.Context, arg1 int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByPK", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteByPK indicates an expected call of DeleteByPK
+func (mr *MockSongRepoMockRecorder) DeleteByPK(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByPK", reflect.TypeOf((*MockSongRepo)(nil).DeleteByPK), arg0, arg1)
+}
+
+// Each mocks base method
+func (m *MockSongRepo) Each(arg0 context.Context, arg1 func(*mysqldb.Song) error, arg2 ...sqkit.SelectOption) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Each", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Each indicates an expected call of Each
+func (mr *MockSongRepoMockRecorder) Each(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Each", reflect.TypeOf((*MockSongRepo)(nil).Each), varargs...)
+}
+
 // Find mocks base method
 func (m *MockSongRepo) Find(arg0 context.Context, arg1 ...sqkit.SelectOption) ([]*mysqldb.Song, error) {
 	m.ctrl.T.Helper()
@@ -105,19 +139,39 @@ func (mr *MockSongRepoMockRecorder) Find(arg0 interface{}, arg1 ...interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockSongRepo)(nil).Find), varargs...)
 }
 
+// FindByPK mocks base method
+func (m *MockSongRepo) FindByPK(arg0 context.Context, arg1 int64) (*mysqldb.Song, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByPK", arg0, arg1)
+	ret0, _ := ret[0].(*mysqldb.Song)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByPK indicates an expected call of FindByPK
+func (mr *MockSongRepoMockRecorder) FindByPK(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByPK", reflect.TypeOf((*MockSongRepo)(nil).FindByPK), arg0, arg1)
+}
+
 // Patch mocks base method
-func (m *MockSongRepo) Patch(arg0 context.Context, arg1 *mysqldb.Song, arg2 sqkit.UpdateOption) (int64, error) {
+func (m *MockSongRepo) Patch(arg0 context.Context, arg1 *mysqldb.Song, arg2 sqkit.UpdateOption, arg3 ...string) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Patch", arg0, arg1, arg2)
+	varargs := []interface{}{arg0, arg1, arg2}
+	for _, a := range arg3 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Patch", varargs...)
 	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Patch indicates an expected call of Patch
-func (mr *MockSongRepoMockRecorder) Patch(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockSongRepoMockRecorder) Patch(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Patch", reflect.TypeOf((*MockSongRepo)(nil).Patch), arg0, arg1, arg2)
+	varargs := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Patch", reflect.TypeOf((*MockSongRepo)(nil).Patch), varargs...)
 }
 
 // Update mocks base method
@@ -3,6 +3,7 @@ package postgresdb_repo
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"time"
 
@@ -10,7 +11,6 @@ import (
 	"github.com/typical-go/typical-go/pkg/typapp"
 	"github.com/typical-go/typical-rest-server/internal/app/data_access/postgresdb"
 	"github.com/typical-go/typical-rest-server/pkg/dbtxn"
-	"github.com/typical-go/typical-rest-server/pkg/reflectkit"
 	"github.com/typical-go/typical-rest-server/pkg/sqkit"
 	"go.uber.org/dig"
 )
@@ -23,14 +23,18 @@ var (
 		ID        string
 		Title     string
 		Author    string
+		Version   string
 		UpdatedAt string
 		CreatedAt string
+		DeletedAt string
 	}{
 		ID:        "id",
 		Title:     "title",
 		Author:    "author",
+		Version:   "version",
 		UpdatedAt: "updated_at",
 		CreatedAt: "created_at",
+		DeletedAt: "deleted_at",
 	}
 )
 
@@ -40,15 +44,20 @@ type (
 	BookRepo interface {
 		Count(context.Context, ...sqkit.SelectOption) (int64, error)
 		Find(context.Context, ...sqkit.SelectOption) ([]*postgresdb.Book, error)
+		Each(context.Context, func(*postgresdb.Book) error, ...sqkit.SelectOption) error
+		FindByPK(context.Context, int64) (*postgresdb.Book, error)
+		DeleteByPK(context.Context, int64) (int64, error)
 		Create(context.Context, *postgresdb.Book) (int64, error)
-		Delete(context.Context, sqkit.DeleteOption) (int64, error)
+		Delete(context.Context, sqkit.SoftDeleteOption) (int64, error)
 		Update(context.Context, *postgresdb.Book, sqkit.UpdateOption) (int64, error)
-		Patch(context.Context, *postgresdb.Book, sqkit.UpdateOption) (int64, error)
+		Patch(context.Context, *postgresdb.Book, sqkit.UpdateOption, ...string) (int64, error)
+		Restore(context.Context, sqkit.UpdateOption) (int64, error)
 	}
 	// BookRepoImpl is implementation books repository
 	BookRepoImpl struct {
 		dig.In
 		*sql.DB `name:"pg"`
+		Replica *sql.DB `name:"pg_replica" optional:"true"`
 	}
 )
 
@@ -61,7 +70,11 @@ func (r *BookRepoImpl) Count(ctx context.Context, opts ...sqkit.SelectOption) (i
 	builder := sq.
 		Select("count(*)").
 		From(BookTableName).
-		RunWith(r)
+		RunWith(dbtxn.Reader(ctx, r.DB, r.Replica))
+
+	if !sqkit.HasIncludeDeleted(opts) {
+		builder = builder.Where(sq.Eq{BookTable.DeletedAt: nil})
+	}
 
 	for _, opt := range opts {
 		builder = opt.CompileSelect(builder)
@@ -76,24 +89,52 @@ func (r *BookRepoImpl) Count(ctx context.Context, opts ...sqkit.SelectOption) (i
 	return cnt, nil
 }
 
-// NewBookRepo return new instance of BookRepo
-func NewBookRepo(impl BookRepoImpl) BookRepo {
-	return &impl
+// NewBookRepo return new instance of BookRepo or error if the database is not available
+func NewBookRepo(impl BookRepoImpl) (BookRepo, error) {
+	if impl.DB == nil {
+		return nil, errors.New("BookRepo: missing database")
+	}
+	return &impl, nil
+}
+
+// BookPK return condition of books primary key
+func BookPK(id int64) sqkit.Eq {
+	return sqkit.Eq{
+		BookTable.ID: id,
+	}
 }
 
 // Find books
-func (r *BookRepoImpl) Find(ctx context.Context, opts ...sqkit.SelectOption) (list []*postgresdb.Book, err error) {
+func (r *BookRepoImpl) Find(ctx context.Context, opts ...sqkit.SelectOption) ([]*postgresdb.Book, error) {
+	list := make([]*postgresdb.Book, 0)
+	if err := r.Each(ctx, func(ent *postgresdb.Book) error {
+		list = append(list, ent)
+		return nil
+	}, opts...); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// Each iterate books row by row without load all rows to memory. The iteration stop when fn return error or context is done
+func (r *BookRepoImpl) Each(ctx context.Context, fn func(*postgresdb.Book) error, opts ...sqkit.SelectOption) error {
 	builder := sq.
 		Select(
 			BookTable.ID,
 			BookTable.Title,
 			BookTable.Author,
+			BookTable.Version,
 			BookTable.UpdatedAt,
 			BookTable.CreatedAt,
+			BookTable.DeletedAt,
 		).
 		From(BookTableName).
 		PlaceholderFormat(sq.Dollar).
-		RunWith(r)
+		RunWith(dbtxn.Reader(ctx, r.DB, r.Replica))
+
+	if !sqkit.HasIncludeDeleted(opts) {
+		builder = builder.Where(sq.Eq{BookTable.DeletedAt: nil})
+	}
 
 	for _, opt := range opts {
 		builder = opt.CompileSelect(builder)
@@ -101,24 +142,48 @@ func (r *BookRepoImpl) Find(ctx context.Context, opts ...sqkit.SelectOption) (li
 
 	rows, err := builder.QueryContext(ctx)
 	if err != nil {
-		return
+		return err
 	}
+	defer rows.Close()
 
-	list = make([]*postgresdb.Book, 0)
 	for rows.Next() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 		ent := new(postgresdb.Book)
-		if err = rows.Scan(
+		if err := rows.Scan(
 			&ent.ID,
 			&ent.Title,
 			&ent.Author,
+			&ent.Version,
 			&ent.UpdatedAt,
 			&ent.CreatedAt,
+			&ent.DeletedAt,
 		); err != nil {
-			return
+			return err
 		}
-		list = append(list, ent)
+		if err := fn(ent); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// FindByPK find books by primary key. Return sql.ErrNoRows if not found
+func (r *BookRepoImpl) FindByPK(ctx context.Context, id int64) (*postgresdb.Book, error) {
+	list, err := r.Find(ctx, BookPK(id))
+	if err != nil {
+		return nil, err
 	}
-	return
+	if len(list) < 1 {
+		return nil, sql.ErrNoRows
+	}
+	return list[0], nil
+}
+
+// DeleteByPK delete books by primary key
+func (r *BookRepoImpl) DeleteByPK(ctx context.Context, id int64) (int64, error) {
+	return r.Delete(ctx, BookPK(id))
 }
 
 // Create books
@@ -128,17 +193,24 @@ func (r *BookRepoImpl) Create(ctx context.Context, ent *postgresdb.Book) (int64,
 		return -1, err
 	}
 
-	scanner := sq.
+	if err := sqkit.BeforeCreate(ctx, ent); err != nil {
+		txn.SetError(err)
+		return -1, err
+	}
+
+	builder := sq.
 		Insert(BookTableName).
 		Columns(
 			BookTable.Title,
 			BookTable.Author,
+			BookTable.Version,
 			BookTable.UpdatedAt,
 			BookTable.CreatedAt,
 		).
 		Values(
 			ent.Title,
 			ent.Author,
+			1,
 			time.Now(),
 			time.Now(),
 		).
@@ -146,15 +218,17 @@ func (r *BookRepoImpl) Create(ctx context.Context, ent *postgresdb.Book) (int64,
 			fmt.Sprintf("RETURNING \"%s\"", BookTable.ID),
 		).
 		PlaceholderFormat(sq.Dollar).
-		RunWith(txn.DB).
-		QueryRowContext(ctx)
+		RunWith(txn.DB)
 
 	var id int64
-	if err := scanner.Scan(&id); err != nil {
+	if err := builder.QueryRowContext(ctx).Scan(&id); err != nil {
 		txn.SetError(err)
 		return -1, err
 	}
-	return id, nil
+	ent.ID = id
+	err = sqkit.AfterCreate(ctx, ent)
+	txn.SetError(err)
+	return id, err
 }
 
 // Update books
@@ -164,11 +238,24 @@ func (r *BookRepoImpl) Update(ctx context.Context, ent *postgresdb.Book, opt sqk
 		return -1, err
 	}
 
+	if ent.Version == 0 {
+		txn.SetError(sqkit.ErrMissingVersion)
+		return -1, sqkit.ErrMissingVersion
+	}
+
+	if err := sqkit.BeforeUpdate(ctx, ent); err != nil {
+		txn.SetError(err)
+		return -1, err
+	}
+
 	builder := sq.
 		Update(BookTableName).
 		Set(BookTable.Title, ent.Title).
 		Set(BookTable.Author, ent.Author).
 		Set(BookTable.UpdatedAt, time.Now()).
+		Set(BookTable.Version, sq.Expr(BookTable.Version+" + 1")).
+		Where(sq.Eq{BookTable.Version: ent.Version}).
+		Where(sq.Eq{BookTable.DeletedAt: nil}).
 		PlaceholderFormat(sq.Dollar).
 		RunWith(txn.DB)
 
@@ -182,26 +269,45 @@ func (r *BookRepoImpl) Update(ctx context.Context, ent *postgresdb.Book, opt sqk
 		return -1, err
 	}
 	affectedRow, err := res.RowsAffected()
+	if err == nil && affectedRow < 1 {
+		err = sqkit.ErrVersionConflict
+	}
+	if err == nil {
+		err = sqkit.AfterUpdate(ctx, ent)
+	}
 	txn.SetError(err)
 	return affectedRow, err
 }
 
 // Patch books
-func (r *BookRepoImpl) Patch(ctx context.Context, ent *postgresdb.Book, opt sqkit.UpdateOption) (int64, error) {
+func (r *BookRepoImpl) Patch(ctx context.Context, ent *postgresdb.Book, opt sqkit.UpdateOption, columns ...string) (int64, error) {
 	txn, err := dbtxn.Use(ctx, r.DB)
 	if err != nil {
 		return -1, err
 	}
 
+	if ent.Version == 0 {
+		txn.SetError(sqkit.ErrMissingVersion)
+		return -1, sqkit.ErrMissingVersion
+	}
+
+	if err := sqkit.BeforePatch(ctx, ent, columns); err != nil {
+		txn.SetError(err)
+		return -1, err
+	}
+
 	builder := sq.
 		Update(BookTableName).
+		Set(BookTable.Version, sq.Expr(BookTable.Version+" + 1")).
+		Where(sq.Eq{BookTable.Version: ent.Version}).
+		Where(sq.Eq{BookTable.DeletedAt: nil}).
 		PlaceholderFormat(sq.Dollar).
 		RunWith(txn.DB)
 
-	if !reflectkit.IsZero(ent.Title) {
+	if sqkit.ShouldPatch(columns, BookTable.Title, ent.Title) {
 		builder = builder.Set(BookTable.Title, ent.Title)
 	}
-	if !reflectkit.IsZero(ent.Author) {
+	if sqkit.ShouldPatch(columns, BookTable.Author, ent.Author) {
 		builder = builder.Set(BookTable.Author, ent.Author)
 	}
 	builder = builder.Set(BookTable.UpdatedAt, time.Now())
@@ -217,24 +323,37 @@ func (r *BookRepoImpl) Patch(ctx context.Context, ent *postgresdb.Book, opt sqki
 	}
 
 	affectedRow, err := res.RowsAffected()
+	if err == nil && affectedRow < 1 {
+		err = sqkit.ErrVersionConflict
+	}
+	if err == nil {
+		err = sqkit.AfterPatch(ctx, ent, columns)
+	}
 	txn.SetError(err)
 	return affectedRow, err
 }
 
-// Delete books
-func (r *BookRepoImpl) Delete(ctx context.Context, opt sqkit.DeleteOption) (int64, error) {
+// Delete books by set deleted_at (soft delete)
+func (r *BookRepoImpl) Delete(ctx context.Context, opt sqkit.SoftDeleteOption) (int64, error) {
 	txn, err := dbtxn.Use(ctx, r.DB)
 	if err != nil {
 		return -1, err
 	}
 
+	if err := sqkit.BeforeDelete(ctx, (*postgresdb.Book)(nil), opt); err != nil {
+		txn.SetError(err)
+		return -1, err
+	}
+
 	builder := sq.
-		Delete(BookTableName).
+		Update(BookTableName).
+		Set(BookTable.DeletedAt, time.Now()).
+		Where(sq.Eq{BookTable.DeletedAt: nil}).
 		PlaceholderFormat(sq.Dollar).
 		RunWith(txn.DB)
 
 	if opt != nil {
-		builder = opt.CompileDelete(builder)
+		builder = opt.CompileUpdate(builder)
 	}
 
 	res, err := builder.ExecContext(ctx)
@@ -244,6 +363,46 @@ func (r *BookRepoImpl) Delete(ctx context.Context, opt sqkit.DeleteOption) (int6
 	}
 
 	affectedRow, err := res.RowsAffected()
+	if err == nil {
+		err = sqkit.AfterDelete(ctx, (*postgresdb.Book)(nil), opt)
+	}
+	txn.SetError(err)
+	return affectedRow, err
+}
+
+// Restore soft-deleted books
+func (r *BookRepoImpl) Restore(ctx context.Context, opt sqkit.UpdateOption) (int64, error) {
+	txn, err := dbtxn.Use(ctx, r.DB)
+	if err != nil {
+		return -1, err
+	}
+
+	if err := sqkit.BeforeRestore(ctx, (*postgresdb.Book)(nil), opt); err != nil {
+		txn.SetError(err)
+		return -1, err
+	}
+
+	builder := sq.
+		Update(BookTableName).
+		Set(BookTable.DeletedAt, nil).
+		Where(sq.NotEq{BookTable.DeletedAt: nil}).
+		PlaceholderFormat(sq.Dollar).
+		RunWith(txn.DB)
+
+	if opt != nil {
+		builder = opt.CompileUpdate(builder)
+	}
+
+	res, err := builder.ExecContext(ctx)
+	if err != nil {
+		txn.SetError(err)
+		return -1, err
+	}
+
+	affectedRow, err := res.RowsAffected()
+	if err == nil {
+		err = sqkit.AfterRestore(ctx, (*postgresdb.Book)(nil), opt)
+	}
 	txn.SetError(err)
 	return affectedRow, err
 }
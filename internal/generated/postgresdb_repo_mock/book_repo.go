@@ -71,7 +71,7 @@ func (mr *MockBookRepoMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call
 }
 
 // Delete mocks base method
-func (m *MockBookRepo) Delete(arg0 context.Context, arg1 sqkit.DeleteOption) (int64, error) {
+func (m *MockBookRepo) Delete(arg0 context.Context, arg1 sqkit.SoftDeleteOption) (int64, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
 	ret0, _ := ret[0].(int64)
@@ -85,6 +85,40 @@ func (mr *MockBookRepoMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockBookRepo)(nil).Delete), arg0, arg1)
 }
 
+// DeleteByPK mocks base method
+func (m *MockBookRepo) DeleteByPK(arg0 context.Context, arg1 int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByPK", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteByPK indicates an expected call of DeleteByPK
+func (mr *MockBookRepoMockRecorder) DeleteByPK(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByPK", reflect.TypeOf((*MockBookRepo)(nil).DeleteByPK), arg0, arg1)
+}
+
+// Each mocks base method
+func (m *MockBookRepo) Each(arg0 context.Context, arg1 func(*postgresdb.Book) error, arg2 ...sqkit.SelectOption) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Each", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Each indicates an expected call of Each
+func (mr *MockBookRepoMockRecorder) Each(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Each", reflect.TypeOf((*MockBookRepo)(nil).Each), varargs...)
+}
+
 // Find mocks base method
 func (m *MockBookRepo) Find(arg0 context.Context, arg1 ...sqkit.SelectOption) ([]*postgresdb.Book, error) {
 	m.ctrl.T.Helper()
@@ -105,19 +139,54 @@ func (mr *MockBookRepoMockRecorder) Find(arg0 interface{}, arg1 ...interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockBookRepo)(nil).Find), varargs...)
 }
 
+// FindByPK mocks base method
+func (m *MockBookRepo) FindByPK(arg0 context.Context, arg1 int64) (*postgresdb.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByPK", arg0, arg1)
+	ret0, _ := ret[0].(*postgresdb.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByPK indicates an expected call of FindByPK
+func (mr *MockBookRepoMockRecorder) FindByPK(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByPK", reflect.TypeOf((*MockBookRepo)(nil).FindByPK), arg0, arg1)
+}
+
 // Patch mocks base method
-func (m *MockBookRepo) Patch(arg0 context.Context, arg1 *postgresdb.Book, arg2 sqkit.UpdateOption) (int64, error) {
+func (m *MockBookRepo) Patch(arg0 context.Context, arg1 *postgresdb.Book, arg2 sqkit.UpdateOption, arg3 ...string) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Patch", arg0, arg1, arg2)
+	varargs := []interface{}{arg0, arg1, arg2}
+	for _, a := range arg3 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Patch", varargs...)
 	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Patch indicates an expected call of Patch
-func (mr *MockBookRepoMockRecorder) Patch(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockBookRepoMockRecorder) Patch(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Patch", reflect.TypeOf((*MockBookRepo)(nil).Patch), varargs...)
+}
+
+// Restore mocks base method
+func (m *MockBookRepo) Restore(arg0 context.Context, arg1 sqkit.UpdateOption) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore
+func (mr *MockBookRepoMockRecorder) Restore(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Patch", reflect.TypeOf((*MockBookRepo)(nil).Patch), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockBookRepo)(nil).Restore), arg0, arg1)
 }
 
 // Update mocks base method
@@ -0,0 +1,439 @@
+package sqlitedb_repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/typical-go/typical-go/pkg/typapp"
+	"github.com/typical-go/typical-rest-server/internal/app/data_access/sqlitedb"
+	"github.com/typical-go/typical-rest-server/pkg/dbtxn"
+	"github.com/typical-go/typical-rest-server/pkg/sqkit"
+	"go.uber.org/dig"
+)
+
+var (
+	// MovieTableName is table name for movies entity
+	MovieTableName = "movies"
+	// MovieTable is columns for movies entity
+	MovieTable = struct {
+		ID        string
+		Title     string
+		Director  string
+		UpdatedAt string
+		CreatedAt string
+		DeletedAt string
+	}{
+		ID:        "id",
+		Title:     "title",
+		Director:  "director",
+		UpdatedAt: "updated_at",
+		CreatedAt: "created_at",
+		DeletedAt: "deleted_at",
+	}
+)
+
+type (
+	// MovieRepo to get movies data from database
+	// @mock
+	MovieRepo interface {
+		Count(context.Context, ...sqkit.SelectOption) (int64, error)
+		Find(context.Context, ...sqkit.SelectOption) ([]*sqlitedb.Movie, error)
+		Each(context.Context, func(*sqlitedb.Movie) error, ...sqkit.SelectOption) error
+		FindByPK(context.Context, int64) (*sqlitedb.Movie, error)
+		DeleteByPK(context.Context, int64) (int64, error)
+		Create(context.Context, *sqlitedb.Movie) (int64, error)
+		Delete(context.Context, sqkit.SoftDeleteOption) (int64, error)
+		Update(context.Context, *sqlitedb.Movie, sqkit.UpdateOption) (int64, error)
+		Patch(context.Context, *sqlitedb.Movie, sqkit.UpdateOption, ...string) (int64, error)
+		Upsert(context.Context, *sqlitedb.Movie) (int64, error)
+		Restore(context.Context, sqkit.UpdateOption) (int64, error)
+	}
+	// MovieRepoImpl is implementation movies repository
+	MovieRepoImpl struct {
+		dig.In
+		*sql.DB `name:"sqlite"`
+		Replica *sql.DB `name:"sqlite_replica" optional:"true"`
+	}
+)
+
+func init() {
+	typapp.Provide("", NewMovieRepo)
+}
+
+// NewMovieRepo return new instance of MovieRepo or error if the database is not available
+func NewMovieRepo(impl MovieRepoImpl) (MovieRepo, error) {
+	if impl.DB == nil {
+		return nil, errors.New("MovieRepo: missing database")
+	}
+	return &impl, nil
+}
+
+// MoviePK return condition of movies primary key
+func MoviePK(id int64) sqkit.Eq {
+	return sqkit.Eq{
+		MovieTable.ID: id,
+	}
+}
+
+// Count movies
+func (r *MovieRepoImpl) Count(ctx context.Context, opts ...sqkit.SelectOption) (int64, error) {
+	builder := sq.
+		Select("count(*)").
+		From(MovieTableName).
+		RunWith(dbtxn.Reader(ctx, r.DB, r.Replica))
+
+	if !sqkit.HasIncludeDeleted(opts) {
+		builder = builder.Where(sq.Eq{MovieTable.DeletedAt: nil})
+	}
+
+	for _, opt := range opts {
+		builder = opt.CompileSelect(builder)
+	}
+
+	row := builder.QueryRowContext(ctx)
+
+	var cnt int64
+	if err := row.Scan(&cnt); err != nil {
+		return -1, err
+	}
+	return cnt, nil
+}
+
+// Find movies
+func (r *MovieRepoImpl) Find(ctx context.Context, opts ...sqkit.SelectOption) ([]*sqlitedb.Movie, error) {
+	list := make([]*sqlitedb.Movie, 0)
+	if err := r.Each(ctx, func(ent *sqlitedb.Movie) error {
+		list = append(list, ent)
+		return nil
+	}, opts...); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// Each iterate movies row by row without load all rows to memory. The iteration stop when fn return error or context is done
+func (r *MovieRepoImpl) Each(ctx context.Context, fn func(*sqlitedb.Movie) error, opts ...sqkit.SelectOption) error {
+	builder := sq.
+		Select(
+			MovieTable.ID,
+			MovieTable.Title,
+			MovieTable.Director,
+			MovieTable.UpdatedAt,
+			MovieTable.CreatedAt,
+			MovieTable.DeletedAt,
+		).
+		From(MovieTableName).
+		RunWith(dbtxn.Reader(ctx, r.DB, r.Replica))
+
+	if !sqkit.HasIncludeDeleted(opts) {
+		builder = builder.Where(sq.Eq{MovieTable.DeletedAt: nil})
+	}
+
+	for _, opt := range opts {
+		builder = opt.CompileSelect(builder)
+	}
+
+	rows, err := builder.QueryContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		ent := new(sqlitedb.Movie)
+		if err := rows.Scan(
+			&ent.ID,
+			&ent.Title,
+			&ent.Director,
+			&ent.UpdatedAt,
+			&ent.CreatedAt,
+			&ent.DeletedAt,
+		); err != nil {
+			return err
+		}
+		if err := fn(ent); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// FindByPK find movies by primary key. Return sql.ErrNoRows if not found
+func (r *MovieRepoImpl) FindByPK(ctx context.Context, id int64) (*sqlitedb.Movie, error) {
+	list, err := r.Find(ctx, MoviePK(id))
+	if err != nil {
+		return nil, err
+	}
+	if len(list) < 1 {
+		return nil, sql.ErrNoRows
+	}
+	return list[0], nil
+}
+
+// DeleteByPK delete movies by primary key
+func (r *MovieRepoImpl) DeleteByPK(ctx context.Context, id int64) (int64, error) {
+	return r.Delete(ctx, MoviePK(id))
+}
+
+// Create movies
+func (r *MovieRepoImpl) Create(ctx context.Context, ent *sqlitedb.Movie) (int64, error) {
+	txn, err := dbtxn.Use(ctx, r.DB)
+	if err != nil {
+		return -1, err
+	}
+
+	if err := sqkit.BeforeCreate(ctx, ent); err != nil {
+		txn.SetError(err)
+		return -1, err
+	}
+
+	res, err := sq.
+		Insert(MovieTableName).
+		Columns(
+			MovieTable.Title,
+			MovieTable.Director,
+			MovieTable.UpdatedAt,
+			MovieTable.CreatedAt,
+		).
+		Values(
+			ent.Title,
+			ent.Director,
+			time.Now(),
+			time.Now(),
+		).
+		RunWith(txn.DB).
+		ExecContext(ctx)
+
+	if err != nil {
+		txn.SetError(err)
+		return -1, err
+	}
+
+	id, err := res.LastInsertId()
+	if err == nil {
+		ent.ID = id
+		err = sqkit.AfterCreate(ctx, ent)
+	}
+	txn.SetError(err)
+	return id, err
+}
+
+// Upsert movies: insert or update when title already exist
+func (r *MovieRepoImpl) Upsert(ctx context.Context, ent *sqlitedb.Movie) (int64, error) {
+	txn, err := dbtxn.Use(ctx, r.DB)
+	if err != nil {
+		return -1, err
+	}
+
+	if err := sqkit.BeforeCreate(ctx, ent); err != nil {
+		txn.SetError(err)
+		return -1, err
+	}
+
+	_, err = sq.
+		Insert(MovieTableName).
+		Columns(
+			MovieTable.Title,
+			MovieTable.Director,
+			MovieTable.UpdatedAt,
+			MovieTable.CreatedAt,
+		).
+		Values(
+			ent.Title,
+			ent.Director,
+			time.Now(),
+			time.Now(),
+		).
+		Suffix(
+			fmt.Sprintf("ON CONFLICT (%s) DO UPDATE SET %s",
+				MovieTable.Title,
+				strings.Join([]string{
+					fmt.Sprintf("%[1]s = excluded.%[1]s", MovieTable.Title),
+					fmt.Sprintf("%[1]s = excluded.%[1]s", MovieTable.Director),
+					fmt.Sprintf("%[1]s = excluded.%[1]s", MovieTable.UpdatedAt),
+					fmt.Sprintf("%s = NULL", MovieTable.DeletedAt), // restore the soft-deleted row
+				}, ", "),
+			),
+		).
+		RunWith(txn.DB).
+		ExecContext(ctx)
+
+	if err != nil {
+		txn.SetError(err)
+		return -1, err
+	}
+
+	// last_insert_rowid() is not updated when the row is updated
+	var id int64
+	err = sq.
+		Select(MovieTable.ID).
+		From(MovieTableName).
+		Where(sq.Eq{MovieTable.Title: ent.Title}).
+		RunWith(txn.DB).
+		QueryRowContext(ctx).
+		Scan(&id)
+	if err == nil {
+		ent.ID = id
+		err = sqkit.AfterCreate(ctx, ent)
+	}
+	txn.SetError(err)
+	return id, err
+}
+
+// Update movies
+func (r *MovieRepoImpl) Update(ctx context.Context, ent *sqlitedb.Movie, opt sqkit.UpdateOption) (int64, error) {
+	txn, err := dbtxn.Use(ctx, r.DB)
+	if err != nil {
+		return -1, err
+	}
+
+	if err := sqkit.BeforeUpdate(ctx, ent); err != nil {
+		txn.SetError(err)
+		return -1, err
+	}
+
+	builder := sq.
+		Update(MovieTableName).
+		Set(MovieTable.Title, ent.Title).
+		Set(MovieTable.Director, ent.Director).
+		Set(MovieTable.UpdatedAt, time.Now()).
+		Where(sq.Eq{MovieTable.DeletedAt: nil}).
+		RunWith(txn.DB)
+
+	if opt != nil {
+		builder = opt.CompileUpdate(builder)
+	}
+
+	res, err := builder.ExecContext(ctx)
+	if err != nil {
+		txn.SetError(err)
+		return -1, err
+	}
+	affectedRow, err := res.RowsAffected()
+	if err == nil {
+		err = sqkit.AfterUpdate(ctx, ent)
+	}
+	txn.SetError(err)
+	return affectedRow, err
+}
+
+// Patch movies
+func (r *MovieRepoImpl) Patch(ctx context.Context, ent *sqlitedb.Movie, opt sqkit.UpdateOption, columns ...string) (int64, error) {
+	txn, err := dbtxn.Use(ctx, r.DB)
+	if err != nil {
+		return -1, err
+	}
+
+	if err := sqkit.BeforePatch(ctx, ent, columns); err != nil {
+		txn.SetError(err)
+		return -1, err
+	}
+
+	builder := sq.Update(MovieTableName).RunWith(txn.DB)
+	builder = builder.Where(sq.Eq{MovieTable.DeletedAt: nil})
+
+	if sqkit.ShouldPatch(columns, MovieTable.Title, ent.Title) {
+		builder = builder.Set(MovieTable.Title, ent.Title)
+	}
+	if sqkit.ShouldPatch(columns, MovieTable.Director, ent.Director) {
+		builder = builder.Set(MovieTable.Director, ent.Director)
+	}
+	builder = builder.Set(MovieTable.UpdatedAt, time.Now())
+
+	if opt != nil {
+		builder = opt.CompileUpdate(builder)
+	}
+
+	res, err := builder.ExecContext(ctx)
+	if err != nil {
+		txn.SetError(err)
+		return -1, err
+	}
+
+	affectedRow, err := res.RowsAffected()
+	if err == nil {
+		err = sqkit.AfterPatch(ctx, ent, columns)
+	}
+	txn.SetError(err)
+	return affectedRow, err
+}
+
+// Delete movies by set deleted_at (soft delete)
+func (r *MovieRepoImpl) Delete(ctx context.Context, opt sqkit.SoftDeleteOption) (int64, error) {
+	txn, err := dbtxn.Use(ctx, r.DB)
+	if err != nil {
+		return -1, err
+	}
+
+	if err := sqkit.BeforeDelete(ctx, (*sqlitedb.Movie)(nil), opt); err != nil {
+		txn.SetError(err)
+		return -1, err
+	}
+
+	builder := sq.
+		Update(MovieTableName).
+		Set(MovieTable.DeletedAt, time.Now()).
+		Where(sq.Eq{MovieTable.DeletedAt: nil}).
+		RunWith(txn.DB)
+
+	if opt != nil {
+		builder = opt.CompileUpdate(builder)
+	}
+
+	res, err := builder.ExecContext(ctx)
+	if err != nil {
+		txn.SetError(err)
+		return -1, err
+	}
+
+	affectedRow, err := res.RowsAffected()
+	if err == nil {
+		err = sqkit.AfterDelete(ctx, (*sqlitedb.Movie)(nil), opt)
+	}
+	txn.SetError(err)
+	return affectedRow, err
+}
+
+// Restore soft-deleted movies
+func (r *MovieRepoImpl) Restore(ctx context.Context, opt sqkit.UpdateOption) (int64, error) {
+	txn, err := dbtxn.Use(ctx, r.DB)
+	if err != nil {
+		return -1, err
+	}
+
+	if err := sqkit.BeforeRestore(ctx, (*sqlitedb.Movie)(nil), opt); err != nil {
+		txn.SetError(err)
+		return -1, err
+	}
+
+	builder := sq.
+		Update(MovieTableName).
+		Set(MovieTable.DeletedAt, nil).
+		Where(sq.NotEq{MovieTable.DeletedAt: nil}).
+		RunWith(txn.DB)
+
+	if opt != nil {
+		builder = opt.CompileUpdate(builder)
+	}
+
+	res, err := builder.ExecContext(ctx)
+	if err != nil {
+		txn.SetError(err)
+		return -1, err
+	}
+
+	affectedRow, err := res.RowsAffected()
+	if err == nil {
+		err = sqkit.AfterRestore(ctx, (*sqlitedb.Movie)(nil), opt)
+	}
+	txn.SetError(err)
+	return affectedRow, err
+}
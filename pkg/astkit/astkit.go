@@ -0,0 +1,58 @@
+package astkit
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"strings"
+)
+
+type (
+	// Struct is struct declaration parsed from go source
+	Struct struct {
+		Pos    token.Position
+		Fields map[string]*Field // keyed by field name
+	}
+	// Field of struct
+	Field struct {
+		Type string // type expression e.g. `*time.Time`, `sql.NullString`
+		Doc  string // doc or line comment in single line
+		Pos  token.Position
+	}
+)
+
+// ParseStruct return position, type and doc of the struct fields which declared in the file.
+// Return struct without fields if not found
+func ParseStruct(path, name string) (*Struct, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	s := &Struct{Fields: make(map[string]*Field)}
+	ast.Inspect(f, func(n ast.Node) bool {
+		spec, ok := n.(*ast.TypeSpec)
+		if !ok || spec.Name.Name != name {
+			return true
+		}
+		s.Pos = fset.Position(spec.Pos())
+		if structType, ok := spec.Type.(*ast.StructType); ok {
+			for _, field := range structType.Fields.List {
+				doc := field.Doc.Text()
+				if doc == "" {
+					doc = field.Comment.Text()
+				}
+				for _, fieldName := range field.Names {
+					s.Fields[fieldName.Name] = &Field{
+						Type: types.ExprString(field.Type),
+						Doc:  strings.Join(strings.Fields(doc), " "),
+						Pos:  fset.Position(fieldName.Pos()),
+					}
+				}
+			}
+		}
+		return false
+	})
+	return s, nil
+}
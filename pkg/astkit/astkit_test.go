@@ -0,0 +1,54 @@
+package astkit_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/typical-go/typical-rest-server/pkg/astkit"
+)
+
+func TestParseStruct(t *testing.T) {
+	dir, err := ioutil.TempDir("", "astkit")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "book.go")
+	require.NoError(t, ioutil.WriteFile(path, []byte(`package mylibrary
+
+type (
+	Author struct{}
+	Book   struct {
+		// ID of
+		// the book
+		ID                   int64
+		Title                string `+"`json:\"title\"`"+` // title of the book
+		CreatedAt, UpdatedAt *time.Time
+	}
+)
+`), 0644))
+
+	s, err := astkit.ParseStruct(path, "Book")
+	require.NoError(t, err)
+	require.Equal(t, 5, s.Pos.Line)
+	require.Equal(t, 2, s.Pos.Column)
+	fields := make(map[string]string)
+	for name, f := range s.Fields {
+		fields[name] = fmt.Sprintf("%d:%d %s %s", f.Pos.Line, f.Pos.Column, f.Type, f.Doc)
+	}
+	require.Equal(t, map[string]string{
+		"ID":        "8:3 int64 ID of the book",
+		"Title":     "9:3 string title of the book",
+		"CreatedAt": "10:3 *time.Time ",
+		"UpdatedAt": "10:14 *time.Time ",
+	}, fields)
+
+	s, err = astkit.ParseStruct(path, "Publisher")
+	require.NoError(t, err)
+	require.Empty(t, s.Fields)
+
+	_, err = astkit.ParseStruct(filepath.Join(dir, "not-found.go"), "Book")
+	require.Error(t, err)
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"sync"
 	"time"
 
 	"github.com/go-redis/redis/v8"
@@ -17,6 +18,7 @@ type (
 		*redis.Client
 		DefaultMaxAge time.Duration
 		PrefixKey     string
+		mx            sync.RWMutex
 	}
 	// Cached ...
 	Cached struct {
@@ -131,7 +133,9 @@ func (s *Store) store(ctx context.Context, key string, rec *httptest.ResponseRec
 func (s *Store) pragma(ctx context.Context, header http.Header, key string) *Pragma {
 	pragma := CreatePragma(header)
 	if pragma.MaxAge < 1 {
+		s.mx.RLock()
 		pragma.MaxAge = s.DefaultMaxAge
+		s.mx.RUnlock()
 	}
 
 	lastModified := ParseTime(s.Client.Get(ctx, key+suffixKeyTime).Val())
@@ -142,6 +146,13 @@ func (s *Store) pragma(ctx context.Context, header http.Header, key string) *Pra
 	return pragma
 }
 
+// SetDefaultMaxAge change default max-age safely while serving (e.g. when config reloaded)
+func (s *Store) SetDefaultMaxAge(maxAge time.Duration) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	s.DefaultMaxAge = maxAge
+}
+
 // FormatTime format time
 func FormatTime(t time.Time) string {
 	return t.In(gmt).Format(time.RFC1123)
@@ -180,3 +180,9 @@ func TestStore_Middleware(t *testing.T) {
 		})
 	}
 }
+
+func TestStore_SetDefaultMaxAge(t *testing.T) {
+	store := &cachekit.Store{DefaultMaxAge: 30 * time.Second}
+	store.SetDefaultMaxAge(time.Minute)
+	require.Equal(t, time.Minute, store.DefaultMaxAge)
+}
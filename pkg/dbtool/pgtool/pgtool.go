@@ -12,6 +12,7 @@ import (
 	"github.com/typical-go/typical-go/pkg/execkit"
 	"github.com/typical-go/typical-go/pkg/typgo"
 	"github.com/typical-go/typical-rest-server/pkg/dbtool"
+	"github.com/typical-go/typical-rest-server/pkg/typrepo"
 	"github.com/urfave/cli/v2"
 
 	// load migration file
@@ -26,6 +27,7 @@ type (
 		DockerName   string
 		MigrationSrc string
 		SeedSrc      string
+		CtorDB       string // verify @entity with the ctor_db (or without ctor_db and same dialect). By default is Name
 		cfg          *dbtool.Config
 	}
 )
@@ -48,6 +50,7 @@ func (t *PgTool) Command(sys *typgo.BuildSys) *cli.Command {
 			{Name: "migrate", Usage: "Migrate database", Action: sys.ExecuteFn(t.MigrateDB)},
 			{Name: "rollback", Usage: "Rollback database", Action: sys.ExecuteFn(t.RollbackDB)},
 			{Name: "seed", Usage: "Seed database", Action: sys.ExecuteFn(t.SeedDB)},
+			{Name: "verify", Usage: "Verify schema drift between @entity and database", Action: sys.ExecuteFn(t.Verify)},
 			{Name: "console", Usage: "Postgres console", Action: sys.ExecuteFn(t.Console)},
 		},
 	}
@@ -153,6 +156,44 @@ func (t *PgTool) SeedDB(c *typgo.Context) error {
 	return nil
 }
 
+// Verify schema drift between @entity and database
+func (t *PgTool) Verify(c *typgo.Context) error {
+	entities, err := typrepo.LoadEntities(c, "@entity")
+	if err != nil {
+		return err
+	}
+	tables := typrepo.EntityTables(entities, "postgres", t.getCtorDB())
+	if len(tables) < 1 {
+		return fmt.Errorf("pg: no @entity with ctor_db:\"%s\" (or without ctor_db and dialect:\"postgres\") to verify", t.getCtorDB())
+	}
+
+	db, err := t.createConn()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	fmt.Fprintf(Stdout, "\npg: Verify %d table(s)\n", len(tables))
+	problems, err := typrepo.VerifySchema(c.Ctx(), db, "postgres", tables)
+	if err != nil {
+		return err
+	}
+	for _, problem := range problems {
+		fmt.Fprintf(Stdout, "  %s\n", problem)
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("pg: schema drift with %d problem(s)", len(problems))
+	}
+	return nil
+}
+
+func (t *PgTool) getCtorDB() string {
+	if t.CtorDB == "" {
+		return t.Name
+	}
+	return t.CtorDB
+}
+
 func (t *PgTool) createMigration() (*migrate.Migrate, error) {
 	db, err := t.createConn()
 	if err != nil {
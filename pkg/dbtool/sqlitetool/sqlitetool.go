@@ -0,0 +1,182 @@
+package sqlitetool
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/golang-migrate/migrate"
+	"github.com/golang-migrate/migrate/database/sqlite3"
+	"github.com/typical-go/typical-go/pkg/execkit"
+	"github.com/typical-go/typical-go/pkg/typgo"
+	"github.com/typical-go/typical-rest-server/pkg/dbtool"
+	"github.com/urfave/cli/v2"
+
+	// load migration file
+	_ "github.com/golang-migrate/migrate/source/file"
+)
+
+type (
+	// SQLiteTool for sqlite. The database file is `DBName` of the config and the command is skipped if it is empty
+	SQLiteTool struct {
+		Name         string
+		ConfigFn     func() dbtool.Configurer
+		MigrationSrc string
+		SeedSrc      string
+		cfg          *dbtool.Config
+	}
+)
+
+var _ (typgo.Cmd) = (*SQLiteTool)(nil)
+
+// Stdout standard output
+var Stdout io.Writer = os.Stdout
+
+// Command for sqlite
+func (t *SQLiteTool) Command(sys *typgo.BuildSys) *cli.Command {
+	return &cli.Command{
+		Name:  t.Name,
+		Usage: t.Name + " utility",
+		Subcommands: []*cli.Command{
+			{Name: "create", Usage: "Create database", Action: sys.ExecuteFn(t.CreateDB)},
+			{Name: "drop", Usage: "Drop database", Action: sys.ExecuteFn(t.DropDB)},
+			{Name: "migrate", Usage: "Migrate database", Action: sys.ExecuteFn(t.MigrateDB)},
+			{Name: "rollback", Usage: "Rollback database", Action: sys.ExecuteFn(t.RollbackDB)},
+			{Name: "seed", Usage: "Seed database", Action: sys.ExecuteFn(t.SeedDB)},
+			{Name: "console", Usage: "SQLite console", Action: sys.ExecuteFn(t.Console)},
+		},
+	}
+}
+
+// Cfg ...
+func (t *SQLiteTool) Cfg() *dbtool.Config {
+	if t.cfg == nil {
+		t.cfg = t.ConfigFn().Config()
+	}
+	return t.cfg
+}
+
+// Console interactive for sqlite (require sqlite3 in local machine)
+func (t *SQLiteTool) Console(c *typgo.Context) error {
+	if t.Cfg().DBName == "" {
+		return errors.New("sqlite: database file is not configured")
+	}
+	return c.Execute(&execkit.Command{
+		Name:   "sqlite3",
+		Args:   []string{t.Cfg().DBName},
+		Stdout: os.Stdout,
+		Stderr: os.Stderr,
+		Stdin:  os.Stdin,
+	})
+}
+
+// CreateDB create database file
+func (t *SQLiteTool) CreateDB(c *typgo.Context) error {
+	if t.skip() {
+		return nil
+	}
+	dbFile := t.Cfg().DBName
+	fmt.Fprintf(Stdout, "\nsqlite: Create '%s'\n", dbFile)
+	if _, err := os.Stat(dbFile); err == nil {
+		return fmt.Errorf("sqlite: '%s' already exist", dbFile)
+	}
+	db, err := t.createConn()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	return db.PingContext(c.Ctx()) // the file created when connection established
+}
+
+// DropDB delete database file
+func (t *SQLiteTool) DropDB(c *typgo.Context) error {
+	if t.skip() {
+		return nil
+	}
+	dbFile := t.Cfg().DBName
+	fmt.Fprintf(Stdout, "\nsqlite: Drop '%s'\n", dbFile)
+	if err := os.Remove(dbFile); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// MigrateDB migrate database
+func (t *SQLiteTool) MigrateDB(c *typgo.Context) error {
+	if t.skip() {
+		return nil
+	}
+	fmt.Fprintf(Stdout, "\nsqlite: Migrate '%s'\n", t.MigrationSrc)
+	migration, err := t.createMigration()
+	if err != nil {
+		return err
+	}
+	defer migration.Close()
+	return migration.Up()
+}
+
+// RollbackDB rollback database
+func (t *SQLiteTool) RollbackDB(c *typgo.Context) error {
+	if t.skip() {
+		return nil
+	}
+	fmt.Fprintf(Stdout, "\nsqlite: Rollback '%s'\n", t.MigrationSrc)
+	migration, err := t.createMigration()
+	if err != nil {
+		return err
+	}
+	defer migration.Close()
+	return migration.Down()
+}
+
+// SeedDB seed database
+func (t *SQLiteTool) SeedDB(c *typgo.Context) error {
+	if t.skip() {
+		return nil
+	}
+	db, err := t.createConn()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	files, _ := ioutil.ReadDir(t.SeedSrc)
+	for _, f := range files {
+		filename := fmt.Sprintf("%s/%s", t.SeedSrc, f.Name())
+		fmt.Fprintf(Stdout, "\nsqlite: Seed '%s'\n", filename)
+		b, _ := ioutil.ReadFile(filename)
+		_, err = db.ExecContext(c.Ctx(), string(b))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// skip the command if the database file is not configured (sqlite is optional)
+func (t *SQLiteTool) skip() bool {
+	if t.Cfg().DBName != "" {
+		return false
+	}
+	fmt.Fprintln(Stdout, "\nsqlite: Skip, database file is not configured")
+	return true
+}
+
+func (t *SQLiteTool) createMigration() (*migrate.Migrate, error) {
+	db, err := t.createConn()
+	if err != nil {
+		return nil, err
+	}
+	driver, err := sqlite3.WithInstance(db, &sqlite3.Config{})
+	if err != nil {
+		return nil, err
+	}
+	return migrate.NewWithDatabaseInstance(t.MigrationSrc, "sqlite3", driver)
+}
+
+func (t *SQLiteTool) createConn() (*sql.DB, error) {
+	return sql.Open("sqlite3", t.Cfg().DBName)
+}
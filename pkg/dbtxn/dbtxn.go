@@ -12,6 +12,8 @@ import (
 // ContextKey to get transaction
 const ContextKey key = iota
 
+const forcePrimaryKey key = 1
+
 type (
 	key int
 	// Context of transaction
@@ -72,6 +74,31 @@ func Find(ctx context.Context) *Context {
 	return c
 }
 
+// ForcePrimary return context to read from the primary database instead of replica. The replica may lag behind
+// the write so use it for read-your-own-writes e.g. return the entity after create/update or read the current
+// version to check `If-Match` before the write
+func ForcePrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, forcePrimaryKey, true)
+}
+
+// Reader return the runner for read query. It is the transaction if already begun, the primary database
+// if in transaction context, forced by ForcePrimary or no replica, otherwise the replica
+func Reader(ctx context.Context, primary, replica *sql.DB) sq.BaseRunner {
+	if c := Find(ctx); c != nil {
+		if c.Tx != nil {
+			return c.Tx
+		}
+		return primary
+	}
+	if replica == nil || ctx == nil {
+		return primary
+	}
+	if force, _ := ctx.Value(forcePrimaryKey).(bool); force {
+		return primary
+	}
+	return replica
+}
+
 // Error of transaction
 func Error(ctx context.Context) error {
 	if c := Find(ctx); c != nil {
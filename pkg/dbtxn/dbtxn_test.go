@@ -124,3 +124,52 @@ func TestSetError(t *testing.T) {
 	handler.SetError(errors.New("some-error"))
 	require.EqualError(t, dbtxn.Error(ctx), "some-error")
 }
+
+func TestReader(t *testing.T) {
+	primary, replica := &sql.DB{}, &sql.DB{}
+	tx := &sql.Tx{}
+	txnCtx := context.Background()
+	dbtxn.Begin(&txnCtx)
+
+	testcases := []struct {
+		TestName string
+		Ctx      context.Context
+		Replica  *sql.DB
+		Expected interface{}
+	}{
+		{
+			TestName: "replica",
+			Ctx:      context.Background(),
+			Replica:  replica,
+			Expected: replica,
+		},
+		{
+			TestName: "no replica",
+			Ctx:      context.Background(),
+			Expected: primary,
+		},
+		{
+			TestName: "force primary",
+			Ctx:      dbtxn.ForcePrimary(context.Background()),
+			Replica:  replica,
+			Expected: primary,
+		},
+		{
+			TestName: "transaction context",
+			Ctx:      txnCtx,
+			Replica:  replica,
+			Expected: primary,
+		},
+		{
+			TestName: "begun transaction",
+			Ctx:      context.WithValue(context.Background(), dbtxn.ContextKey, &dbtxn.Context{Tx: tx}),
+			Replica:  replica,
+			Expected: tx,
+		},
+	}
+	for _, tt := range testcases {
+		t.Run(tt.TestName, func(t *testing.T) {
+			require.True(t, tt.Expected == dbtxn.Reader(tt.Ctx, primary, tt.Replica))
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package echokit
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"sort"
+
+	"github.com/labstack/echo/v4"
+)
+
+// BindPatch bind the request to i and return the keys available in JSON body.
+// The keys distinguish absent field from explicit zero or null value for partial update
+func BindPatch(ec echo.Context, i interface{}) ([]string, error) {
+	req := ec.Request()
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = ioutil.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+	if err := ec.Bind(i); err != nil {
+		return nil, err
+	}
+
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(body, &m); err != nil {
+		return nil, nil // not JSON body
+	}
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
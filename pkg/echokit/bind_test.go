@@ -0,0 +1,70 @@
+package echokit_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+	"github.com/typical-go/typical-rest-server/pkg/echokit"
+)
+
+func TestBindPatch(t *testing.T) {
+	type entity struct {
+		Title  string  `json:"title"`
+		Author *string `json:"author"`
+	}
+	testcases := []struct {
+		testName     string
+		contentType  string
+		body         string
+		expected     entity
+		expectedKeys []string
+		expectedErr  string
+	}{
+		{
+			testName:     "explicit zero and null",
+			contentType:  echo.MIMEApplicationJSON,
+			body:         `{"title":"","author":null}`,
+			expectedKeys: []string{"author", "title"},
+		},
+		{
+			testName:     "absent field",
+			contentType:  echo.MIMEApplicationJSON,
+			body:         `{"title":"some-title"}`,
+			expected:     entity{Title: "some-title"},
+			expectedKeys: []string{"title"},
+		},
+		{
+			testName:    "bad json",
+			contentType: echo.MIMEApplicationJSON,
+			body:        `{"title":`,
+			expectedErr: "code=400, message=unexpected EOF, internal=unexpected EOF",
+		},
+		{
+			testName:    "not json",
+			contentType: echo.MIMEApplicationForm,
+			body:        `title=some-title`,
+			expected:    entity{Title: "some-title"},
+		},
+	}
+	for _, tt := range testcases {
+		t.Run(tt.testName, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPatch, "/", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, tt.contentType)
+			ec := echo.New().NewContext(req, httptest.NewRecorder())
+
+			var ent entity
+			keys, err := echokit.BindPatch(ec, &ent)
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, ent)
+			require.Equal(t, tt.expectedKeys, keys)
+		})
+	}
+}
@@ -3,4 +3,8 @@ package echokit
 const (
 	// HeaderTotalCount header total count
 	HeaderTotalCount = "X-Total-Count"
+	// HeaderETag header entity-tag
+	HeaderETag = "ETag"
+	// HeaderIfMatch header for conditional request
+	HeaderIfMatch = "If-Match"
 )
@@ -1,20 +1,170 @@
 package echokit
 
 import (
+	"context"
+	"database/sql"
+	"errors"
 	"net/http"
 
+	"github.com/go-sql-driver/mysql"
 	"github.com/labstack/echo/v4"
+	"github.com/lib/pq"
+	"github.com/typical-go/typical-rest-server/pkg/sqkit"
 )
 
+type (
+	// ErrorClass map the matched error to HTTP status code
+	ErrorClass struct {
+		Status  int
+		Match   func(error) bool
+		Message string // public message instead of the error message (kept as internal error) e.g. for driver error
+	}
+	classErr struct {
+		class error
+		err   error
+	}
+)
+
+// Sentinel error of error classification. Wrap the error with Wrap (or `%w`) to get the HTTP status code
+var (
+	ErrNotFound   = errors.New("not found")
+	ErrConflict   = errors.New("conflict")
+	ErrValidation = errors.New("validation")
+	ErrTimeout    = errors.New("timeout")
+)
+
+// Public message of the built-in error classes. The error message of the database driver and standard library
+// contain internal detail e.g. table, constraint or the value
+const (
+	MessageNotFound            = "not found"
+	MessageVersionConflict     = "version conflict, the resource has been modified"
+	MessageMissingVersion      = "missing version of the resource"
+	MessageUniqueViolation     = "duplicate value of unique field"
+	MessageForeignKeyViolation = "conflict with referenced data"
+	MessageTimeout             = "timeout"
+)
+
+// ErrorClasses is registry of error classification which checked in order. The unmatched error is 500.
+// The error wrapped into sentinel error of echokit (see Wrap) keep its message as public message
+var ErrorClasses = []*ErrorClass{
+	{Status: http.StatusNotFound, Match: IsAny(ErrNotFound)},
+	{Status: http.StatusNotFound, Match: IsAny(sql.ErrNoRows), Message: MessageNotFound},
+	{Status: http.StatusConflict, Match: IsAny(ErrConflict)},
+	{Status: http.StatusConflict, Match: IsAny(sqkit.ErrVersionConflict), Message: MessageVersionConflict},
+	{Status: http.StatusPreconditionRequired, Match: IsAny(sqkit.ErrMissingVersion), Message: MessageMissingVersion},
+	{Status: http.StatusConflict, Match: IsUniqueViolation, Message: MessageUniqueViolation},
+	{Status: http.StatusConflict, Match: IsForeignKeyViolation, Message: MessageForeignKeyViolation},
+	{Status: http.StatusUnprocessableEntity, Match: IsAny(ErrValidation)},
+	{Status: http.StatusGatewayTimeout, Match: IsAny(ErrTimeout)},
+	{Status: http.StatusGatewayTimeout, Match: IsAny(context.DeadlineExceeded), Message: MessageTimeout},
+}
+
 // NewValidErr create ValidationError
 func NewValidErr(message string) *echo.HTTPError {
 	return echo.NewHTTPError(http.StatusUnprocessableEntity, message)
 }
 
-// HTTPError convert error to *echo.HTTPError
+// HTTPError convert error to *echo.HTTPError according ErrorClasses. The error message is replaced by the public
+// message of the class (if any) or the status text for unmatched error, and the error is kept as internal error
 func HTTPError(err error) *echo.HTTPError {
-	if httpErr, ok := err.(*echo.HTTPError); ok {
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) {
 		return httpErr
 	}
-	return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	class := classify(err)
+	if class == nil {
+		return echo.NewHTTPError(http.StatusInternalServerError).SetInternal(err)
+	}
+	if class.Message != "" {
+		return echo.NewHTTPError(class.Status, class.Message).SetInternal(err)
+	}
+	return echo.NewHTTPError(class.Status, err.Error())
+}
+
+// RegisterError register error classification. It take precedence over the existing classification
+func RegisterError(status int, match func(error) bool) {
+	RegisterErrorClass(&ErrorClass{Status: status, Match: match})
+}
+
+// RegisterErrorClass register error classification with public message. It take precedence over the existing
+// classification
+func RegisterErrorClass(class *ErrorClass) {
+	ErrorClasses = append([]*ErrorClass{class}, ErrorClasses...)
+}
+
+// StatusCode return HTTP status code of the error according ErrorClasses
+func StatusCode(err error) int {
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.Code
+	}
+	if class := classify(err); class != nil {
+		return class.Status
+	}
+	return http.StatusInternalServerError
+}
+
+func classify(err error) *ErrorClass {
+	for _, class := range ErrorClasses {
+		if class.Match(err) {
+			return class
+		}
+	}
+	return nil
+}
+
+// Wrap the error into the class (e.g. ErrNotFound) while keep the error message and the original error
+func Wrap(class, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &classErr{class: class, err: err}
+}
+
+func (e *classErr) Error() string { return e.err.Error() }
+
+func (e *classErr) Unwrap() error { return e.err }
+
+func (e *classErr) Is(target error) bool { return target == e.class }
+
+// IsAny return matcher of the targets (using errors.Is)
+func IsAny(targets ...error) func(error) bool {
+	return func(err error) bool {
+		for _, target := range targets {
+			if errors.Is(err, target) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// IsUniqueViolation return true if unique constraint violation of postgres (23505) or mysql (1062).
+// See sqliteerr package for sqlite
+func IsUniqueViolation(err error) bool {
+	return isPqError(err, "23505") || isMySQLError(err, 1062)
+}
+
+// IsForeignKeyViolation return true if foreign key constraint violation of postgres (23503) or mysql (1451, 1452).
+// See sqliteerr package for sqlite
+func IsForeignKeyViolation(err error) bool {
+	return isPqError(err, "23503") || isMySQLError(err, 1451, 1452)
+}
+
+func isPqError(err error, code pq.ErrorCode) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == code
+}
+
+func isMySQLError(err error, numbers ...uint16) bool {
+	var mysqlErr *mysql.MySQLError
+	if !errors.As(err, &mysqlErr) {
+		return false
+	}
+	for _, number := range numbers {
+		if mysqlErr.Number == number {
+			return true
+		}
+	}
+	return false
 }
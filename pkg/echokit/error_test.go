@@ -1,13 +1,19 @@
 package echokit_test
 
 import (
+	"context"
+	"database/sql"
 	"errors"
+	"fmt"
 	"net/http"
 	"testing"
 
+	"github.com/go-sql-driver/mysql"
 	"github.com/labstack/echo/v4"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/require"
 	"github.com/typical-go/typical-rest-server/pkg/echokit"
+	"github.com/typical-go/typical-rest-server/pkg/sqkit"
 )
 
 func TestHTTPError(t *testing.T) {
@@ -17,13 +23,59 @@ func TestHTTPError(t *testing.T) {
 		expected *echo.HTTPError
 	}{
 		{
+			TestName: "unknown error is hidden",
 			err:      errors.New("some-error"),
-			expected: echo.NewHTTPError(http.StatusInternalServerError, "some-error"),
+			expected: echo.NewHTTPError(http.StatusInternalServerError).SetInternal(errors.New("some-error")),
 		},
 		{
 			err:      echo.NewHTTPError(99, "some-message"),
 			expected: echo.NewHTTPError(99, "some-message"),
 		},
+		{
+			TestName: "wrapped http error",
+			err:      fmt.Errorf("some-context: %w", echo.NewHTTPError(99, "some-message")),
+			expected: echo.NewHTTPError(99, "some-message"),
+		},
+		{
+			TestName: "version conflict",
+			err:      fmt.Errorf("update: %w", sqkit.ErrVersionConflict),
+			expected: echo.NewHTTPError(http.StatusConflict, echokit.MessageVersionConflict).
+				SetInternal(fmt.Errorf("update: %w", sqkit.ErrVersionConflict)),
+		},
+		{
+			TestName: "no rows",
+			err:      fmt.Errorf("find: %w", sql.ErrNoRows),
+			expected: echo.NewHTTPError(http.StatusNotFound, "not found").
+				SetInternal(fmt.Errorf("find: %w", sql.ErrNoRows)),
+		},
+		{
+			TestName: "deadline exceeded",
+			err:      fmt.Errorf("query: %w", context.DeadlineExceeded),
+			expected: echo.NewHTTPError(http.StatusGatewayTimeout, "timeout").
+				SetInternal(fmt.Errorf("query: %w", context.DeadlineExceeded)),
+		},
+		{
+			TestName: "wrapped into not found class keep the message",
+			err:      echokit.Wrap(echokit.ErrNotFound, errors.New("no affected row")),
+			expected: echo.NewHTTPError(http.StatusNotFound, "no affected row"),
+		},
+		{
+			TestName: "driver error is replaced by public message",
+			err:      fmt.Errorf("insert: %w", &pq.Error{Code: "23505", Message: `duplicate key value violates unique constraint "books_isbn_key"`}),
+			expected: echo.NewHTTPError(http.StatusConflict, "duplicate value of unique field").
+				SetInternal(fmt.Errorf("insert: %w", &pq.Error{Code: "23505", Message: `duplicate key value violates unique constraint "books_isbn_key"`})),
+		},
+		{
+			TestName: "foreign key violation",
+			err:      &mysql.MySQLError{Number: 1452, Message: "Cannot add or update a child row"},
+			expected: echo.NewHTTPError(http.StatusConflict, "conflict with referenced data").
+				SetInternal(&mysql.MySQLError{Number: 1452, Message: "Cannot add or update a child row"}),
+		},
+		{
+			TestName: "wrapped into class",
+			err:      echokit.Wrap(echokit.ErrValidation, errors.New("some-invalid")),
+			expected: echo.NewHTTPError(http.StatusUnprocessableEntity, "some-invalid"),
+		},
 	}
 	for _, tt := range testcases {
 		t.Run(tt.TestName, func(t *testing.T) {
@@ -32,6 +84,57 @@ func TestHTTPError(t *testing.T) {
 	}
 }
 
+func TestStatusCode(t *testing.T) {
+	testcases := []struct {
+		TestName string
+		err      error
+		expected int
+	}{
+		{TestName: "unknown", err: errors.New("some-error"), expected: 500},
+		{TestName: "http error", err: echo.ErrForbidden, expected: 403},
+		{TestName: "not found", err: fmt.Errorf("some-context: %w", echokit.ErrNotFound), expected: 404},
+		{TestName: "no rows", err: sql.ErrNoRows, expected: 404},
+		{TestName: "conflict", err: echokit.Wrap(echokit.ErrConflict, errors.New("some-error")), expected: 409},
+		{TestName: "version conflict", err: sqkit.ErrVersionConflict, expected: 409},
+		{TestName: "missing version", err: sqkit.ErrMissingVersion, expected: 428},
+		{TestName: "pq unique violation", err: &pq.Error{Code: "23505"}, expected: 409},
+		{TestName: "pq foreign key violation", err: fmt.Errorf("insert: %w", &pq.Error{Code: "23503"}), expected: 409},
+		{TestName: "pq other", err: &pq.Error{Code: "42P01"}, expected: 500},
+		{TestName: "mysql duplicate entry", err: &mysql.MySQLError{Number: 1062}, expected: 409},
+		{TestName: "mysql foreign key violation", err: &mysql.MySQLError{Number: 1452}, expected: 409},
+		{TestName: "mysql other", err: &mysql.MySQLError{Number: 1146}, expected: 500},
+		{TestName: "validation", err: echokit.Wrap(echokit.ErrValidation, errors.New("some-error")), expected: 422},
+		{TestName: "timeout", err: echokit.Wrap(echokit.ErrTimeout, errors.New("some-error")), expected: 504},
+		{TestName: "deadline exceeded", err: fmt.Errorf("query: %w", context.DeadlineExceeded), expected: 504},
+	}
+	for _, tt := range testcases {
+		t.Run(tt.TestName, func(t *testing.T) {
+			require.Equal(t, tt.expected, echokit.StatusCode(tt.err))
+		})
+	}
+}
+
+func TestRegisterError(t *testing.T) {
+	defer func(classes []*echokit.ErrorClass) { echokit.ErrorClasses = classes }(echokit.ErrorClasses)
+
+	errSome := errors.New("some-error")
+	echokit.RegisterError(http.StatusTeapot, echokit.IsAny(errSome))
+	echokit.RegisterError(http.StatusGone, echokit.IsAny(sql.ErrNoRows)) // take precedence
+
+	require.Equal(t, http.StatusTeapot, echokit.StatusCode(fmt.Errorf("some-context: %w", errSome)))
+	require.Equal(t, http.StatusGone, echokit.StatusCode(sql.ErrNoRows))
+}
+
+func TestWrap(t *testing.T) {
+	require.NoError(t, echokit.Wrap(echokit.ErrNotFound, nil))
+
+	err := echokit.Wrap(echokit.ErrNotFound, sql.ErrNoRows)
+	require.EqualError(t, err, "sql: no rows in result set")
+	require.True(t, errors.Is(err, echokit.ErrNotFound))
+	require.True(t, errors.Is(err, sql.ErrNoRows))
+	require.False(t, errors.Is(err, echokit.ErrConflict))
+}
+
 func TestNewValidErr(t *testing.T) {
 	require.Equal(t, echo.NewHTTPError(422, "some-message"), echokit.NewValidErr("some-message"))
 }
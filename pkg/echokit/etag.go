@@ -0,0 +1,31 @@
+package echokit
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+)
+
+// ETag return strong entity-tag (quoted) generated from the values
+func ETag(values ...interface{}) string {
+	h := fnv.New64a()
+	for _, v := range values {
+		fmt.Fprintf(h, "%v;", v)
+	}
+	return fmt.Sprintf("\"%016x\"", h.Sum64())
+}
+
+// MatchETag return true if If-Match header value match the etag. Empty header or `*` always match.
+// Weak entity-tag (prefix `W/`) never match as If-Match use strong comparison
+func MatchETag(ifMatch, etag string) bool {
+	if ifMatch == "" {
+		return true
+	}
+	for _, tag := range strings.Split(ifMatch, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" || tag == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,35 @@
+package echokit_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/typical-go/typical-rest-server/pkg/echokit"
+)
+
+func TestETag(t *testing.T) {
+	require.Equal(t, echokit.ETag(1, "a"), echokit.ETag(1, "a"))
+	require.NotEqual(t, echokit.ETag(1, "a"), echokit.ETag(1, "b"))
+	require.Equal(t, "\"e2d317f0e08e9ff5\"", echokit.ETag(1, "a"))
+}
+
+func TestMatchETag(t *testing.T) {
+	testcases := []struct {
+		testName string
+		ifMatch  string
+		etag     string
+		expected bool
+	}{
+		{testName: "no header", ifMatch: "", etag: "\"abc\"", expected: true},
+		{testName: "any", ifMatch: "*", etag: "\"abc\"", expected: true},
+		{testName: "match", ifMatch: "\"abc\"", etag: "\"abc\"", expected: true},
+		{testName: "match in list", ifMatch: "\"xyz\", \"abc\"", etag: "\"abc\"", expected: true},
+		{testName: "not match", ifMatch: "\"xyz\"", etag: "\"abc\"", expected: false},
+		{testName: "weak tag", ifMatch: "W/\"abc\"", etag: "\"abc\"", expected: false},
+	}
+	for _, tt := range testcases {
+		t.Run(tt.testName, func(t *testing.T) {
+			require.Equal(t, tt.expected, echokit.MatchETag(tt.ifMatch, tt.etag))
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package echokit
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// MIMEApplicationProblemJSON content type of problem details
+const MIMEApplicationProblemJSON = "application/problem+json"
+
+type (
+	// Problem details for HTTP APIs (RFC 7807)
+	Problem struct {
+		Type      string `json:"type"`
+		Title     string `json:"title"`
+		Status    int    `json:"status"`
+		Detail    string `json:"detail,omitempty"`
+		Instance  string `json:"instance,omitempty"`
+		RequestID string `json:"request_id,omitempty"`
+	}
+)
+
+// ProblemType is type URI of problem details. Default is `about:blank` which mean the problem has no additional semantic than the status code
+var ProblemType = "about:blank"
+
+// NewProblem create problem details of the error where the detail of server error (5xx) is hidden unless debug
+// (the internal error is shown in debug mode)
+func NewProblem(c echo.Context, err error, debug bool) *Problem {
+	httpErr := HTTPError(err)
+	p := &Problem{
+		Type:      ProblemType,
+		Title:     http.StatusText(httpErr.Code),
+		Status:    httpErr.Code,
+		Instance:  c.Request().URL.Path,
+		RequestID: requestID(c),
+	}
+	switch {
+	case httpErr.Code < http.StatusInternalServerError:
+		p.Detail = problemDetail(httpErr)
+	case debug && httpErr.Internal != nil:
+		p.Detail = httpErr.Internal.Error()
+	case debug:
+		p.Detail = problemDetail(httpErr)
+	}
+	if p.Detail == p.Title {
+		p.Detail = ""
+	}
+	return p
+}
+
+// ProblemErrorHandler is echo.HTTPErrorHandler to render the error as `application/problem+json`.
+// The detail of server error (5xx) is only shown in debug mode (echo.Echo#Debug) and logged instead
+func ProblemErrorHandler(err error, c echo.Context) {
+	handleProblem(err, c, c.Echo().Debug)
+}
+
+// NewProblemErrorHandler return ProblemErrorHandler with debug mode from the function instead of echo.Echo#Debug
+// e.g. debug flag which changed on config reload while serving
+func NewProblemErrorHandler(debug func() bool) echo.HTTPErrorHandler {
+	return func(err error, c echo.Context) {
+		handleProblem(err, c, debug())
+	}
+}
+
+func handleProblem(err error, c echo.Context, debug bool) {
+	if c.Response().Committed {
+		return
+	}
+	e := c.Echo()
+	p := NewProblem(c, err, debug)
+	if p.Status >= http.StatusInternalServerError {
+		e.Logger.Error(err)
+	}
+
+	if c.Request().Method == http.MethodHead {
+		err = c.NoContent(p.Status)
+	} else {
+		err = writeProblem(c, p)
+	}
+	if err != nil {
+		e.Logger.Error(err)
+	}
+}
+
+func writeProblem(c echo.Context, p *Problem) error {
+	b, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+	return c.Blob(p.Status, MIMEApplicationProblemJSON, b)
+}
+
+func problemDetail(httpErr *echo.HTTPError) string {
+	switch msg := httpErr.Message.(type) {
+	case nil:
+		return ""
+	case string:
+		return msg
+	case error:
+		return msg.Error()
+	default:
+		return fmt.Sprint(msg)
+	}
+}
+
+func requestID(c echo.Context) string {
+	if id := c.Response().Header().Get(echo.HeaderXRequestID); id != "" {
+		return id
+	}
+	return c.Request().Header.Get(echo.HeaderXRequestID)
+}
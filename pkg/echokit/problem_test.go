@@ -0,0 +1,106 @@
+package echokit_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+	"github.com/typical-go/typical-rest-server/pkg/echokit"
+)
+
+func TestProblemErrorHandler(t *testing.T) {
+	testcases := []struct {
+		TestName       string
+		method         string
+		err            error
+		debug          bool
+		requestID      string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			TestName:       "client error",
+			method:         http.MethodGet,
+			err:            echokit.NewValidErr("some-message"),
+			requestID:      "some-id",
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"type":"about:blank","title":"Unprocessable Entity","status":422,"detail":"some-message","instance":"/some-path","request_id":"some-id"}`,
+		},
+		{
+			TestName:       "no message",
+			method:         http.MethodGet,
+			err:            echo.ErrNotFound,
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"type":"about:blank","title":"Not Found","status":404,"instance":"/some-path"}`,
+		},
+		{
+			TestName:       "internal error is hidden",
+			method:         http.MethodGet,
+			err:            errors.New("pq: relation \"books\" does not exist"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"type":"about:blank","title":"Internal Server Error","status":500,"instance":"/some-path"}`,
+		},
+		{
+			TestName:       "internal error in debug mode",
+			method:         http.MethodGet,
+			err:            errors.New("some-error"),
+			debug:          true,
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"some-error","instance":"/some-path"}`,
+		},
+		{
+			TestName:       "head request",
+			method:         http.MethodHead,
+			err:            echo.ErrNotFound,
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range testcases {
+		t.Run(tt.TestName, func(t *testing.T) {
+			e := echo.New()
+			e.Debug = tt.debug
+			req := httptest.NewRequest(tt.method, "/some-path?q=1", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			if tt.requestID != "" {
+				c.Response().Header().Set(echo.HeaderXRequestID, tt.requestID)
+			}
+
+			echokit.ProblemErrorHandler(tt.err, c)
+			require.Equal(t, tt.expectedStatus, rec.Code)
+			require.Equal(t, tt.expectedBody, rec.Body.String())
+			if tt.expectedBody != "" {
+				require.Equal(t, echokit.MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
+			}
+		})
+	}
+}
+
+func TestNewProblemErrorHandler(t *testing.T) {
+	debug := false
+	handler := echokit.NewProblemErrorHandler(func() bool { return debug })
+	e := echo.New()
+	e.Debug = true // ignored
+
+	rec := httptest.NewRecorder()
+	handler(errors.New("some-error"), e.NewContext(httptest.NewRequest(http.MethodGet, "/some-path", nil), rec))
+	require.Equal(t, `{"type":"about:blank","title":"Internal Server Error","status":500,"instance":"/some-path"}`, rec.Body.String())
+
+	debug = true
+	rec = httptest.NewRecorder()
+	handler(errors.New("some-error"), e.NewContext(httptest.NewRequest(http.MethodGet, "/some-path", nil), rec))
+	require.Equal(t, `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"some-error","instance":"/some-path"}`, rec.Body.String())
+}
+
+func TestProblemErrorHandler_Committed(t *testing.T) {
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec)
+	c.String(http.StatusOK, "some-response")
+
+	echokit.ProblemErrorHandler(errors.New("some-error"), c)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "some-response", rec.Body.String())
+}
@@ -0,0 +1,24 @@
+// Package sqliteerr classify the error of sqlite driver (github.com/mattn/go-sqlite3) which require cgo.
+// It is separated from echokit so echokit doesn't register the driver and force cgo to its consumer
+package sqliteerr
+
+import (
+	"net/http"
+
+	"github.com/typical-go/typical-rest-server/pkg/echokit"
+)
+
+// Register classification of sqlite constraint violation to echokit.ErrorClasses with the same public message as
+// other database
+func Register() {
+	echokit.RegisterErrorClass(&echokit.ErrorClass{
+		Status:  http.StatusConflict,
+		Match:   IsForeignKeyViolation,
+		Message: echokit.MessageForeignKeyViolation,
+	})
+	echokit.RegisterErrorClass(&echokit.ErrorClass{
+		Status:  http.StatusConflict,
+		Match:   IsUniqueViolation,
+		Message: echokit.MessageUniqueViolation,
+	})
+}
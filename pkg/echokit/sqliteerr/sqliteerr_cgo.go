@@ -0,0 +1,33 @@
+//go:build cgo
+// +build cgo
+
+package sqliteerr
+
+import (
+	"errors"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// IsUniqueViolation return true if SQLITE_CONSTRAINT_UNIQUE or SQLITE_CONSTRAINT_PRIMARYKEY
+func IsUniqueViolation(err error) bool {
+	return isSqliteError(err, sqlite3.ErrConstraintUnique, sqlite3.ErrConstraintPrimaryKey)
+}
+
+// IsForeignKeyViolation return true if SQLITE_CONSTRAINT_FOREIGNKEY
+func IsForeignKeyViolation(err error) bool {
+	return isSqliteError(err, sqlite3.ErrConstraintForeignKey)
+}
+
+func isSqliteError(err error, codes ...sqlite3.ErrNoExtended) bool {
+	var sqliteErr sqlite3.Error
+	if !errors.As(err, &sqliteErr) {
+		return false
+	}
+	for _, code := range codes {
+		if sqliteErr.ExtendedCode == code {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,12 @@
+//go:build !cgo
+// +build !cgo
+
+package sqliteerr
+
+// sqlite driver require cgo so there is no sqlite error to check
+
+// IsUniqueViolation always false without cgo
+func IsUniqueViolation(err error) bool { return false }
+
+// IsForeignKeyViolation always false without cgo
+func IsForeignKeyViolation(err error) bool { return false }
@@ -0,0 +1,65 @@
+//go:build cgo
+// +build cgo
+
+package sqliteerr_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+	"github.com/typical-go/typical-rest-server/pkg/echokit"
+	"github.com/typical-go/typical-rest-server/pkg/echokit/sqliteerr"
+)
+
+func TestRegister(t *testing.T) {
+	defer func(classes []*echokit.ErrorClass) { echokit.ErrorClasses = classes }(echokit.ErrorClasses)
+	sqliteerr.Register()
+
+	testcases := []struct {
+		TestName string
+		err      error
+		expected int
+	}{
+		{
+			TestName: "unique violation",
+			err:      sqlite3.Error{Code: sqlite3.ErrConstraint, ExtendedCode: sqlite3.ErrConstraintUnique},
+			expected: 409,
+		},
+		{
+			TestName: "primary key violation",
+			err:      fmt.Errorf("insert: %w", sqlite3.Error{Code: sqlite3.ErrConstraint, ExtendedCode: sqlite3.ErrConstraintPrimaryKey}),
+			expected: 409,
+		},
+		{
+			TestName: "foreign key violation",
+			err:      sqlite3.Error{Code: sqlite3.ErrConstraint, ExtendedCode: sqlite3.ErrConstraintForeignKey},
+			expected: 409,
+		},
+		{
+			TestName: "other constraint",
+			err:      sqlite3.Error{Code: sqlite3.ErrConstraint, ExtendedCode: sqlite3.ErrConstraintNotNull},
+			expected: 500,
+		},
+		{
+			TestName: "not driver error",
+			err:      errors.New("UNIQUE constraint failed: books.title"),
+			expected: 500,
+		},
+	}
+	for _, tt := range testcases {
+		t.Run(tt.TestName, func(t *testing.T) {
+			require.Equal(t, tt.expected, echokit.StatusCode(tt.err))
+		})
+	}
+
+	err := sqlite3.Error{Code: sqlite3.ErrConstraint, ExtendedCode: sqlite3.ErrConstraintUnique}
+	require.Equal(t,
+		echo.NewHTTPError(http.StatusConflict, echokit.MessageUniqueViolation).SetInternal(err),
+		echokit.HTTPError(err),
+	)
+}
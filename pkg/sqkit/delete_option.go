@@ -9,6 +9,11 @@ type (
 	DeleteOption interface {
 		CompileDelete(sq.DeleteBuilder) sq.DeleteBuilder
 	}
+	// SoftDeleteOption to compile delete query and soft delete query (which is update query) e.g. Eq
+	SoftDeleteOption interface {
+		DeleteOption
+		UpdateOption
+	}
 	// CompileDeleteFn function
 	CompileDeleteFn  func(sq.DeleteBuilder) sq.DeleteBuilder
 	deleteOptionImpl struct {
@@ -12,6 +12,7 @@ type (
 var _ SelectOption = (Eq)(nil)
 var _ UpdateOption = (Eq)(nil)
 var _ DeleteOption = (Eq)(nil)
+var _ SoftDeleteOption = (Eq)(nil)
 
 // CompileSelect to compile select query for filtering
 func (e Eq) CompileSelect(base sq.SelectBuilder) sq.SelectBuilder {
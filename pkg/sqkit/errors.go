@@ -0,0 +1,10 @@
+package sqkit
+
+import "errors"
+
+var (
+	// ErrVersionConflict returned when update/patch versioned entity not affecting any row (stale version)
+	ErrVersionConflict = errors.New("sqkit: version conflict")
+	// ErrMissingVersion returned when update/patch versioned entity without version (zero) instead of query with version 0
+	ErrMissingVersion = errors.New("sqkit: missing version")
+)
@@ -0,0 +1,129 @@
+package sqkit
+
+import "context"
+
+type (
+	// BeforeCreateHook is called by repository before insert the entity. Return error to abort the insert
+	BeforeCreateHook interface {
+		BeforeCreate(ctx context.Context) error
+	}
+	// AfterCreateHook is called by repository after the entity inserted
+	AfterCreateHook interface {
+		AfterCreate(ctx context.Context) error
+	}
+	// BeforeUpdateHook is called by repository before update the entity. Return error to abort the update
+	BeforeUpdateHook interface {
+		BeforeUpdate(ctx context.Context) error
+	}
+	// AfterUpdateHook is called by repository after the entity updated
+	AfterUpdateHook interface {
+		AfterUpdate(ctx context.Context) error
+	}
+	// BeforePatchHook is called by repository before patch the entity. Return error to abort the patch
+	BeforePatchHook interface {
+		BeforePatch(ctx context.Context, columns []string) error
+	}
+	// AfterPatchHook is called by repository after the entity patched
+	AfterPatchHook interface {
+		AfterPatch(ctx context.Context, columns []string) error
+	}
+	// BeforeDeleteHook is called by repository before delete with the delete condition. The deleted rows are not
+	// loaded so the receiver is nil pointer. Return error to abort the delete
+	BeforeDeleteHook interface {
+		BeforeDelete(ctx context.Context, opt DeleteOption) error
+	}
+	// AfterDeleteHook is called by repository after delete with the delete condition on nil pointer receiver
+	AfterDeleteHook interface {
+		AfterDelete(ctx context.Context, opt DeleteOption) error
+	}
+	// BeforeRestoreHook is called by repository before restore soft-deleted rows with the restore condition
+	// on nil pointer receiver. Return error to abort the restore
+	BeforeRestoreHook interface {
+		BeforeRestore(ctx context.Context, opt UpdateOption) error
+	}
+	// AfterRestoreHook is called by repository after restore soft-deleted rows with the restore condition
+	// on nil pointer receiver
+	AfterRestoreHook interface {
+		AfterRestore(ctx context.Context, opt UpdateOption) error
+	}
+)
+
+// BeforeCreate call the hook if the entity implement BeforeCreateHook
+func BeforeCreate(ctx context.Context, ent interface{}) error {
+	if h, ok := ent.(BeforeCreateHook); ok {
+		return h.BeforeCreate(ctx)
+	}
+	return nil
+}
+
+// AfterCreate call the hook if the entity implement AfterCreateHook
+func AfterCreate(ctx context.Context, ent interface{}) error {
+	if h, ok := ent.(AfterCreateHook); ok {
+		return h.AfterCreate(ctx)
+	}
+	return nil
+}
+
+// BeforeUpdate call the hook if the entity implement BeforeUpdateHook
+func BeforeUpdate(ctx context.Context, ent interface{}) error {
+	if h, ok := ent.(BeforeUpdateHook); ok {
+		return h.BeforeUpdate(ctx)
+	}
+	return nil
+}
+
+// AfterUpdate call the hook if the entity implement AfterUpdateHook
+func AfterUpdate(ctx context.Context, ent interface{}) error {
+	if h, ok := ent.(AfterUpdateHook); ok {
+		return h.AfterUpdate(ctx)
+	}
+	return nil
+}
+
+// BeforePatch call the hook if the entity implement BeforePatchHook
+func BeforePatch(ctx context.Context, ent interface{}, columns []string) error {
+	if h, ok := ent.(BeforePatchHook); ok {
+		return h.BeforePatch(ctx, columns)
+	}
+	return nil
+}
+
+// AfterPatch call the hook if the entity implement AfterPatchHook
+func AfterPatch(ctx context.Context, ent interface{}, columns []string) error {
+	if h, ok := ent.(AfterPatchHook); ok {
+		return h.AfterPatch(ctx, columns)
+	}
+	return nil
+}
+
+// BeforeDelete call the hook if the entity implement BeforeDeleteHook
+func BeforeDelete(ctx context.Context, ent interface{}, opt DeleteOption) error {
+	if h, ok := ent.(BeforeDeleteHook); ok {
+		return h.BeforeDelete(ctx, opt)
+	}
+	return nil
+}
+
+// AfterDelete call the hook if the entity implement AfterDeleteHook
+func AfterDelete(ctx context.Context, ent interface{}, opt DeleteOption) error {
+	if h, ok := ent.(AfterDeleteHook); ok {
+		return h.AfterDelete(ctx, opt)
+	}
+	return nil
+}
+
+// BeforeRestore call the hook if the entity implement BeforeRestoreHook
+func BeforeRestore(ctx context.Context, ent interface{}, opt UpdateOption) error {
+	if h, ok := ent.(BeforeRestoreHook); ok {
+		return h.BeforeRestore(ctx, opt)
+	}
+	return nil
+}
+
+// AfterRestore call the hook if the entity implement AfterRestoreHook
+func AfterRestore(ctx context.Context, ent interface{}, opt UpdateOption) error {
+	if h, ok := ent.(AfterRestoreHook); ok {
+		return h.AfterRestore(ctx, opt)
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package sqkit_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/typical-go/typical-rest-server/pkg/sqkit"
+)
+
+type hookEntity struct {
+	Title  string
+	Called []string
+}
+
+func (e *hookEntity) BeforeCreate(ctx context.Context) error {
+	e.Title = strings.TrimSpace(e.Title)
+	e.Called = append(e.Called, "before-create")
+	return nil
+}
+
+func (e *hookEntity) AfterCreate(ctx context.Context) error {
+	return errors.New("after-create-error")
+}
+
+func (e *hookEntity) BeforePatch(ctx context.Context, columns []string) error {
+	e.Called = append(e.Called, "before-patch:"+strings.Join(columns, ","))
+	return nil
+}
+
+func (e *hookEntity) BeforeDelete(ctx context.Context, opt sqkit.DeleteOption) error {
+	if opt == nil {
+		return errors.New("missing delete condition")
+	}
+	return nil
+}
+
+func (e *hookEntity) AfterRestore(ctx context.Context, opt sqkit.UpdateOption) error {
+	if e != nil {
+		return errors.New("restore hook called on entity")
+	}
+	return nil
+}
+
+func TestHook(t *testing.T) {
+	ctx := context.Background()
+	ent := &hookEntity{Title: " some-title "}
+
+	require.NoError(t, sqkit.BeforeCreate(ctx, ent))
+	require.EqualError(t, sqkit.AfterCreate(ctx, ent), "after-create-error")
+	require.NoError(t, sqkit.BeforeUpdate(ctx, ent))
+	require.NoError(t, sqkit.AfterUpdate(ctx, ent))
+	require.NoError(t, sqkit.BeforePatch(ctx, ent, []string{"title"}))
+	require.NoError(t, sqkit.AfterPatch(ctx, ent, []string{"title"}))
+	require.EqualError(t, sqkit.BeforeDelete(ctx, ent, nil), "missing delete condition")
+	require.NoError(t, sqkit.BeforeDelete(ctx, ent, sqkit.Eq{"id": 1}))
+	require.NoError(t, sqkit.AfterDelete(ctx, ent, nil))
+	require.NoError(t, sqkit.BeforeRestore(ctx, ent, nil))
+
+	require.Equal(t, &hookEntity{
+		Title:  "some-title",
+		Called: []string{"before-create", "before-patch:title"},
+	}, ent)
+}
+
+func TestHook_NilReceiver(t *testing.T) {
+	ctx := context.Background()
+	var ent *hookEntity
+
+	require.EqualError(t, sqkit.BeforeDelete(ctx, ent, nil), "missing delete condition")
+	require.NoError(t, sqkit.AfterDelete(ctx, ent, sqkit.Eq{"id": 1}))
+	require.NoError(t, sqkit.AfterRestore(ctx, ent, sqkit.Eq{"id": 1}))
+	require.EqualError(t, sqkit.AfterRestore(ctx, &hookEntity{}, nil), "restore hook called on entity")
+}
+
+func TestHook_NotImplemented(t *testing.T) {
+	ctx := context.Background()
+	ent := &struct{}{}
+
+	require.NoError(t, sqkit.BeforeCreate(ctx, ent))
+	require.NoError(t, sqkit.AfterCreate(ctx, ent))
+	require.NoError(t, sqkit.BeforeUpdate(ctx, ent))
+	require.NoError(t, sqkit.AfterUpdate(ctx, ent))
+	require.NoError(t, sqkit.BeforePatch(ctx, ent, nil))
+	require.NoError(t, sqkit.AfterPatch(ctx, ent, nil))
+	require.NoError(t, sqkit.BeforeDelete(ctx, ent, nil))
+	require.NoError(t, sqkit.AfterDelete(ctx, ent, nil))
+	require.NoError(t, sqkit.BeforeRestore(ctx, ent, nil))
+	require.NoError(t, sqkit.AfterRestore(ctx, ent, nil))
+}
@@ -0,0 +1,28 @@
+package sqkit
+
+import (
+	sq "github.com/Masterminds/squirrel"
+)
+
+type (
+	// IncludeDeleted select option to include soft-deleted rows
+	IncludeDeleted struct{}
+)
+
+var _ SelectOption = (*IncludeDeleted)(nil)
+
+// CompileSelect return the base as is. Soft-delete filtering is handled by repository
+func (IncludeDeleted) CompileSelect(base sq.SelectBuilder) sq.SelectBuilder {
+	return base
+}
+
+// HasIncludeDeleted return true if IncludeDeleted is in the options
+func HasIncludeDeleted(opts []SelectOption) bool {
+	for _, opt := range opts {
+		switch opt.(type) {
+		case IncludeDeleted, *IncludeDeleted:
+			return true
+		}
+	}
+	return false
+}
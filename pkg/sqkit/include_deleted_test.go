@@ -0,0 +1,44 @@
+package sqkit_test
+
+import (
+	"testing"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/stretchr/testify/require"
+	"github.com/typical-go/typical-rest-server/pkg/sqkit"
+)
+
+func TestIncludeDeleted(t *testing.T) {
+	base := sq.Select("name").From("some-table")
+	require.Equal(t, base, sqkit.IncludeDeleted{}.CompileSelect(base))
+}
+
+func TestHasIncludeDeleted(t *testing.T) {
+	testcases := []struct {
+		testName string
+		opts     []sqkit.SelectOption
+		expected bool
+	}{
+		{
+			opts:     nil,
+			expected: false,
+		},
+		{
+			opts:     []sqkit.SelectOption{sqkit.Eq{"name": "dummy-name"}, &sqkit.OffsetPagination{}},
+			expected: false,
+		},
+		{
+			opts:     []sqkit.SelectOption{sqkit.Eq{"name": "dummy-name"}, sqkit.IncludeDeleted{}},
+			expected: true,
+		},
+		{
+			opts:     []sqkit.SelectOption{&sqkit.IncludeDeleted{}},
+			expected: true,
+		},
+	}
+	for _, tt := range testcases {
+		t.Run(tt.testName, func(t *testing.T) {
+			require.Equal(t, tt.expected, sqkit.HasIncludeDeleted(tt.opts))
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package sqkit
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/typical-go/typical-rest-server/pkg/reflectkit"
+)
+
+// ShouldPatch return true if the column should be set by patch operation.
+// If columns is given, only the listed column is patched (including explicit zero or null value).
+// Otherwise, the column is patched only if the value is not zero.
+func ShouldPatch(columns []string, column string, v interface{}) bool {
+	if len(columns) > 0 {
+		for _, c := range columns {
+			if c == column {
+				return true
+			}
+		}
+		return false
+	}
+	return !reflectkit.IsZero(v)
+}
+
+// JSONColumns return the columns (`column` tag) of the entity field with the given JSON keys (`json` tag)
+func JSONColumns(ent interface{}, keys ...string) []string {
+	typ := reflect.TypeOf(ent)
+	for typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ == nil || typ.Kind() != reflect.Struct {
+		return nil
+	}
+
+	keyMap := make(map[string]bool)
+	for _, key := range keys {
+		keyMap[key] = true
+	}
+
+	var columns []string
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if name := jsonName(field); name != "" && keyMap[name] {
+			column := field.Tag.Get("column")
+			if column == "" {
+				column = strings.ToLower(field.Name)
+			}
+			columns = append(columns, column)
+		}
+	}
+	return columns
+}
+
+func jsonName(field reflect.StructField) string {
+	name := strings.Split(field.Tag.Get("json"), ",")[0]
+	if name == "-" {
+		return ""
+	}
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
@@ -0,0 +1,74 @@
+package sqkit_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/typical-go/typical-rest-server/pkg/sqkit"
+)
+
+func TestShouldPatch(t *testing.T) {
+	var emptyStr string
+	testcases := []struct {
+		testName string
+		columns  []string
+		column   string
+		v        interface{}
+		expected bool
+	}{
+		{testName: "non-zero value", column: "title", v: "some-title", expected: true},
+		{testName: "zero value", column: "title", v: "", expected: false},
+		{testName: "nil pointer", column: "title", v: (*string)(nil), expected: false},
+		{testName: "pointer to zero value", column: "title", v: &emptyStr, expected: true},
+		{testName: "invalid sql null", column: "title", v: sql.NullString{}, expected: false},
+		{testName: "valid sql null", column: "title", v: sql.NullString{Valid: true}, expected: true},
+		{testName: "listed column with zero value", columns: []string{"author", "title"}, column: "title", v: "", expected: true},
+		{testName: "listed column with nil pointer", columns: []string{"title"}, column: "title", v: (*string)(nil), expected: true},
+		{testName: "unlisted column", columns: []string{"author"}, column: "title", v: "some-title", expected: false},
+	}
+	for _, tt := range testcases {
+		t.Run(tt.testName, func(t *testing.T) {
+			require.Equal(t, tt.expected, sqkit.ShouldPatch(tt.columns, tt.column, tt.v))
+		})
+	}
+}
+
+func TestJSONColumns(t *testing.T) {
+	type entity struct {
+		ID        int64   `column:"id" json:"id"`
+		Title     string  `column:"title" json:"title"`
+		Note      *string `column:"note" json:"note,omitempty"`
+		UpdatedAt string  `column:"updated_at" json:"update_at"`
+		Author    string
+		Secret    string `column:"secret" json:"-"`
+	}
+	testcases := []struct {
+		testName string
+		ent      interface{}
+		keys     []string
+		expected []string
+	}{
+		{
+			ent:      &entity{},
+			keys:     []string{"title", "note", "update_at", "Author", "unknown"},
+			expected: []string{"title", "note", "updated_at", "author"},
+		},
+		{
+			testName: "no keys",
+			ent:      entity{},
+			expected: nil,
+		},
+		{
+			testName: "not struct",
+			ent:      "some-text",
+			keys:     []string{"title"},
+			expected: nil,
+		},
+	}
+	for _, tt := range testcases {
+		t.Run(tt.testName, func(t *testing.T) {
+			require.Equal(t, tt.expected, sqkit.JSONColumns(tt.ent, tt.keys...))
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package typcfg
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/typical-go/typical-go/pkg/envkit"
+	"github.com/typical-go/typical-go/pkg/typast"
+	"github.com/typical-go/typical-go/pkg/typgo"
+	"github.com/urfave/cli/v2"
+)
+
+type (
+	// CheckCmd report config drift of dotenv files against @envconfig structs i.e. unknown keys
+	// and missing required keys without default value. Return error if any issue found (for CI)
+	CheckCmd struct {
+		Name     string   // By default is "check-config"
+		TagName  string   // By default is "@envconfig"
+		DotEnvs  []string // Complete dotenv files. By default are `.env` and `.env.sample`
+		Overlays []string // Glob of partial dotenv (e.g. profile) which only checked for unknown keys. By default is `.env.*`
+	}
+)
+
+var _ typgo.Cmd = (*CheckCmd)(nil)
+var _ typgo.Action = (*CheckCmd)(nil)
+
+// Command check config
+func (m *CheckCmd) Command(sys *typgo.BuildSys) *cli.Command {
+	return &cli.Command{
+		Name:   m.getName(),
+		Usage:  "Check unknown and missing keys of dotenv against @envconfig",
+		Action: sys.Action(m),
+	}
+}
+
+// Execute check config
+func (m *CheckCmd) Execute(c *typgo.Context) error {
+	ac, err := (&typast.AnnotateProject{}).CreateContext(c)
+	if err != nil {
+		return err
+	}
+	configs := (&EnvconfigAnnotation{TagName: m.getTagName()}).Context(ac).Configs
+
+	var issues []string
+	complete := make(map[string]bool)
+	for _, file := range m.getDotEnvs() {
+		complete[file] = true
+		envmap, err := envkit.ReadFile(file)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		issues = append(issues, CheckDotEnv(configs, file, envmap, true)...)
+	}
+	for _, pattern := range m.getOverlays() {
+		files, _ := filepath.Glob(pattern)
+		for _, file := range files {
+			if complete[file] {
+				continue
+			}
+			envmap, err := envkit.ReadFile(file)
+			if err != nil {
+				return err
+			}
+			issues = append(issues, CheckDotEnv(configs, file, envmap, false)...)
+		}
+	}
+
+	for _, issue := range issues {
+		fmt.Fprintln(Stdout, issue)
+	}
+	if len(issues) > 0 {
+		return fmt.Errorf("config drift: %d issue(s)", len(issues))
+	}
+	fmt.Fprintln(Stdout, "No config drift")
+	return nil
+}
+
+// CheckDotEnv return issues of the dotenv: unknown keys and (if complete) missing required keys without default value
+func CheckDotEnv(configs []*Envconfig, file string, envmap envkit.Map, complete bool) []string {
+	known := map[string]bool{ProfileEnv: true, ConfigFileEnv: true}
+	for _, config := range configs {
+		for _, field := range config.Fields {
+			known[field.Key] = true
+			if field.Secret {
+				known[field.Key+"_FILE"] = true
+			}
+		}
+	}
+
+	var issues []string
+	for _, key := range envmap.SortedKeys() {
+		if !known[key] {
+			issues = append(issues, fmt.Sprintf("%s: %s: unknown key", file, key))
+		}
+	}
+	if !complete {
+		return issues
+	}
+	var missing []string
+	for _, config := range configs {
+		for _, field := range config.Fields {
+			if !field.Required || field.Default != "" || envmap[field.Key] != "" {
+				continue
+			}
+			if field.Secret && envmap[field.Key+"_FILE"] != "" {
+				continue
+			}
+			missing = append(missing, fmt.Sprintf("%s: %s: missing required key", file, field.Key))
+		}
+	}
+	sort.Strings(missing)
+	return append(issues, missing...)
+}
+
+func (m *CheckCmd) getName() string {
+	if m.Name == "" {
+		m.Name = "check-config"
+	}
+	return m.Name
+}
+
+func (m *CheckCmd) getTagName() string {
+	if m.TagName == "" {
+		m.TagName = "@envconfig"
+	}
+	return m.TagName
+}
+
+func (m *CheckCmd) getDotEnvs() []string {
+	if len(m.DotEnvs) < 1 {
+		m.DotEnvs = []string{".env", ".env.sample"}
+	}
+	return m.DotEnvs
+}
+
+func (m *CheckCmd) getOverlays() []string {
+	if len(m.Overlays) < 1 {
+		m.Overlays = []string{".env.*"}
+	}
+	return m.Overlays
+}
@@ -0,0 +1,55 @@
+package typcfg_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/typical-go/typical-go/pkg/envkit"
+	"github.com/typical-go/typical-rest-server/pkg/typcfg"
+)
+
+func TestCheckDotEnv(t *testing.T) {
+	configs := []*typcfg.Envconfig{
+		{
+			Fields: []*typcfg.Field{
+				{Key: "DB_NAME", Required: true},
+				{Key: "DB_HOST", Required: true, Default: "localhost"},
+				{Key: "DB_PASS", Required: true, Secret: true},
+				{Key: "DB_DEBUG"},
+			},
+		},
+	}
+	testcases := []struct {
+		TestName string
+		EnvMap   envkit.Map
+		Complete bool
+		Expected []string
+	}{
+		{
+			TestName: "no drift",
+			EnvMap:   envkit.Map{"DB_NAME": "some-name", "DB_PASS_FILE": "/run/secrets/db_pass", "DOTENV_PROFILE": "test"},
+			Complete: true,
+		},
+		{
+			TestName: "unknown and missing keys",
+			EnvMap:   envkit.Map{"DB_NAME": "", "DB_PASSWORD": "some-pass", "REDIS_HOST": "localhost"},
+			Complete: true,
+			Expected: []string{
+				"some-env: DB_PASSWORD: unknown key",
+				"some-env: REDIS_HOST: unknown key",
+				"some-env: DB_NAME: missing required key",
+				"some-env: DB_PASS: missing required key",
+			},
+		},
+		{
+			TestName: "overlay only check unknown key",
+			EnvMap:   envkit.Map{"DB_DEBUG": "true", "DB_USER": "some-user"},
+			Expected: []string{"some-env: DB_USER: unknown key"},
+		},
+	}
+	for _, tt := range testcases {
+		t.Run(tt.TestName, func(t *testing.T) {
+			require.Equal(t, tt.Expected, typcfg.CheckDotEnv(configs, "some-env", tt.EnvMap, tt.Complete))
+		})
+	}
+}
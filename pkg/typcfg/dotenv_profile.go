@@ -0,0 +1,55 @@
+package typcfg
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/typical-go/typical-go/pkg/envkit"
+	"github.com/urfave/cli/v2"
+)
+
+// ProfileEnv is environment variable to select dotenv profile e.g. `DOTENV_PROFILE=test` to overlay `.env.test` to `.env`
+var ProfileEnv = "DOTENV_PROFILE"
+
+// DotEnvProfile add `--profile` flag to the cli app to select dotenv profile (or using ProfileEnv).
+// The profile dotenv is overlaid after the base dotenv loaded
+func DotEnvProfile(app *cli.App, dotenv string) {
+	app.Flags = append(app.Flags, &cli.StringFlag{
+		Name:    "profile",
+		Usage:   fmt.Sprintf("Dotenv profile to overlay '%s' e.g. `test` for '%s'", dotenv, ProfileFile(dotenv, "test")),
+		EnvVars: []string{ProfileEnv},
+	})
+	before := app.Before
+	app.Before = func(c *cli.Context) error {
+		if before != nil {
+			if err := before(c); err != nil {
+				return err
+			}
+		}
+		if profile := c.String("profile"); profile != "" {
+			// NOTE: pass the profile to sub-process (e.g. test and run)
+			os.Setenv(ProfileEnv, profile)
+		}
+		return LoadProfile(dotenv)
+	}
+}
+
+// LoadProfile overlay dotenv of selected profile (from ProfileEnv) to environment variable
+func LoadProfile(dotenv string) error {
+	profile := os.Getenv(ProfileEnv)
+	if profile == "" {
+		return nil
+	}
+	target := ProfileFile(dotenv, profile)
+	envmap, err := envkit.ReadFile(target)
+	if err != nil {
+		return fmt.Errorf("profile '%s': %w", profile, err)
+	}
+	fmt.Fprintf(Stdout, "Load environment '%s' %s\n", target, envmap.SortedKeys())
+	return envkit.Setenv(envmap)
+}
+
+// ProfileFile return dotenv of the profile e.g. `.env.test`
+func ProfileFile(dotenv, profile string) string {
+	return fmt.Sprintf("%s.%s", dotenv, profile)
+}
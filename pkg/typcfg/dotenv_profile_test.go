@@ -0,0 +1,92 @@
+package typcfg_test
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/typical-go/typical-rest-server/pkg/typcfg"
+	"github.com/urfave/cli/v2"
+)
+
+func TestLoadProfile(t *testing.T) {
+	ioutil.WriteFile("some-env.test", []byte("key1=val111\nkey3=val333"), 0777)
+	defer os.Remove("some-env.test")
+
+	testcases := []struct {
+		TestName    string
+		Profile     string
+		Expected    map[string]string
+		ExpectedOut string
+		ExpectedErr string
+	}{
+		{
+			TestName: "no profile",
+			Expected: map[string]string{"key1": "val1", "key2": "val2", "key3": ""},
+		},
+		{
+			TestName:    "overlay profile",
+			Profile:     "test",
+			Expected:    map[string]string{"key1": "val111", "key2": "val2", "key3": "val333"},
+			ExpectedOut: "Load environment 'some-env.test' [key1 key3]\n",
+		},
+		{
+			TestName:    "profile not found",
+			Profile:     "local",
+			ExpectedErr: "profile 'local': open some-env.local: no such file or directory",
+		},
+	}
+	for _, tt := range testcases {
+		t.Run(tt.TestName, func(t *testing.T) {
+			var out strings.Builder
+			typcfg.Stdout = &out
+			defer func() { typcfg.Stdout = os.Stdout }()
+			defer os.Clearenv()
+			os.Setenv("key1", "val1")
+			os.Setenv("key2", "val2")
+			os.Setenv("DOTENV_PROFILE", tt.Profile)
+
+			err := typcfg.LoadProfile("some-env")
+			if tt.ExpectedErr != "" {
+				require.EqualError(t, err, tt.ExpectedErr)
+				return
+			}
+			require.NoError(t, err)
+			for k, v := range tt.Expected {
+				require.Equal(t, v, os.Getenv(k))
+			}
+			require.Equal(t, tt.ExpectedOut, out.String())
+		})
+	}
+}
+
+func TestDotEnvProfile(t *testing.T) {
+	ioutil.WriteFile("some-env.test", []byte("key1=val111"), 0777)
+	defer os.Remove("some-env.test")
+	defer os.Clearenv()
+
+	var out strings.Builder
+	typcfg.Stdout = &out
+	defer func() { typcfg.Stdout = os.Stdout }()
+
+	var called bool
+	app := &cli.App{
+		Before: func(*cli.Context) error {
+			os.Setenv("key1", "val1")
+			return nil
+		},
+		Action: func(*cli.Context) error {
+			called = true
+			return nil
+		},
+	}
+	typcfg.DotEnvProfile(app, "some-env")
+
+	require.NoError(t, app.Run([]string{"app", "--profile", "test"}))
+	require.True(t, called)
+	require.Equal(t, "val111", os.Getenv("key1"))
+	require.Equal(t, "test", os.Getenv("DOTENV_PROFILE"))
+	require.Equal(t, "Load environment 'some-env.test' [key1]\n", out.String())
+}
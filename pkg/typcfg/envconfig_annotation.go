@@ -11,17 +11,20 @@ import (
 	"github.com/typical-go/typical-go/pkg/tmplkit"
 	"github.com/typical-go/typical-go/pkg/typast"
 	"github.com/typical-go/typical-go/pkg/typgo"
+	"github.com/typical-go/typical-rest-server/pkg/astkit"
 )
 
 type (
 	// EnvconfigAnnotation handle @envconfig annotation
 	// e.g. `@envconfig (prefix: "PREFIX" ctor:"CTOR")`
 	EnvconfigAnnotation struct {
-		TagName  string // By default is `@envconfig`
-		Template string // By default defined in defaultCfgTemplate variable
-		Target   string // By default is `cmd/PROJECT_NAME/envconfig_annotated.go`
-		DotEnv   string // Dotenv path. It will be generated if not empty
-		UsageDoc string // Usage documentation path. It will be if not emtpy
+		TagName       string // By default is `@envconfig`
+		Template      string // By default defined in defaultCfgTemplate variable
+		Target        string // By default is `cmd/PROJECT_NAME/envconfig_annotated.go`
+		DotEnv        string // Dotenv path. It will be generated if not empty
+		UsageDoc      string // Usage documentation path. It will be if not emtpy
+		JSONSchema    string // JSON Schema path of environment variables. It will be generated if not empty
+		ConfigExample string // Example of config file (YAML). It will be generated if not empty
 	}
 	// EnvconfigTmplData template
 	EnvconfigTmplData struct {
@@ -44,12 +47,19 @@ type (
 		Name     string
 		Fields   []*Field
 		FnName   string
+		OnReload string // name of function to register reload callback. Empty if no reloadable field
 	}
 	// Field model
 	Field struct {
 		Key      string
+		Name     string // field name
+		Type     string // go type e.g. `time.Duration`
+		Desc     string // from `desc` tag or field comment
 		Default  string
 		Required bool
+		Secret   bool   // excluded from dotenv and masked in usage
+		Reload   bool   // changed by Reload without restart
+		Validate string // `validate` tag
 	}
 )
 
@@ -72,11 +82,19 @@ func init() { {{if .Configs}}{{range $c := .Configs}}
 func {{$c.FnName}}() (*{{$c.SpecType}}, error) {
 	var cfg {{$c.SpecType}}
 	prefix := "{{$c.Prefix}}"
-	if err := envconfig.Process(prefix, &cfg); err != nil {
+	if err := typcfg.Process(prefix, &cfg); err != nil {
 		return nil, fmt.Errorf("%s: %w", prefix, err)
 	}
 	return &cfg, nil
-}{{end}}
+}{{if $c.OnReload}}
+
+// {{$c.OnReload}} register callback when reloadable field of {{$c.Name}} changed
+func {{$c.OnReload}}(fn func(*{{$c.SpecType}})) {
+	typcfg.OnReload("{{$c.Prefix}}",
+		func() (interface{}, error) { return {{$c.FnName}}() },
+		func(cfg interface{}) { fn(cfg.(*{{$c.SpecType}})) },
+	)
+}{{end}}{{end}}
 `
 
 //
@@ -108,6 +126,18 @@ func (m *EnvconfigAnnotation) Annotate(c *typast.Context) error {
 		}
 	}
 
+	if m.JSONSchema != "" {
+		if err := GenerateJSONSchema(m.JSONSchema, context); err != nil {
+			return err
+		}
+	}
+
+	if m.ConfigExample != "" {
+		if err := GenerateConfigExample(m.ConfigExample, context); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -116,8 +146,8 @@ func (m *EnvconfigAnnotation) Context(c *typast.Context) *Context {
 	var configs []*Envconfig
 
 	annots, imports := typast.FindAnnot(c, m.getTagName(), typast.EqualStruct)
-	imports["github.com/kelseyhightower/envconfig"] = ""
 	imports["github.com/typical-go/typical-go/pkg/typapp"] = ""
+	imports["github.com/typical-go/typical-rest-server/pkg/typcfg"] = ""
 	imports["fmt"] = ""
 
 	for _, annot := range annots {
@@ -179,20 +209,39 @@ func createEnvconfig(a *typast.Annot2) *Envconfig {
 
 	name := a.GetName()
 	ctor := getCtorName(a)
-	return &Envconfig{
+	cfg := &Envconfig{
 		Ctor:     ctor,
 		Name:     name,
 		Prefix:   prefix,
 		SpecType: fmt.Sprintf("%s.%s", a.ImportAlias, name),
-		Fields:   createFields(structDecl, prefix),
+		Fields:   createFields(a.File.Path, structDecl, prefix),
 		FnName:   fmt.Sprintf("Load%s%s", strcase.ToCamel(ctor), name),
 	}
+	for _, field := range cfg.Fields {
+		if field.Reload {
+			cfg.OnReload = fmt.Sprintf("On%s%sReload", strcase.ToCamel(ctor), name)
+		}
+	}
+	return cfg
 }
 
-func createFields(structDecl *typast.StructDecl, prefix string) []*Field {
+func createFields(path string, structDecl *typast.StructDecl, prefix string) []*Field {
+	// NOTE: typast.Field doesn't have comment and selector type (e.g. `time.Duration`)
+	var infos map[string]*astkit.Field
+	if s, err := astkit.ParseStruct(path, structDecl.Name); err == nil {
+		infos = s.Fields
+	}
+
 	var fields []*Field
 	for _, field := range structDecl.Fields {
-		fields = append(fields, CreateField(prefix, field))
+		f := CreateField(prefix, field)
+		if info, ok := infos[f.Name]; ok {
+			f.Type = info.Type
+			if f.Desc == "" {
+				f.Desc = info.Doc
+			}
+		}
+		fields = append(fields, f)
 	}
 	return fields
 }
@@ -208,8 +257,14 @@ func CreateField(prefix string, field *typast.Field) *Field {
 
 	return &Field{
 		Key:      fmt.Sprintf("%s_%s", prefix, name),
+		Name:     field.Names[0],
+		Type:     field.Type,
+		Desc:     field.Get("desc"),
 		Default:  field.Get("default"),
 		Required: field.Get("required") == "true",
+		Secret:   field.Get("secret") == "true",
+		Reload:   field.Get("reload") == "true",
+		Validate: field.Get("validate"),
 	}
 }
 
@@ -60,8 +60,8 @@ func TestCfgAnnotation_Annotate(t *testing.T) {
 
 import (
 	 "fmt"
-	 "github.com/kelseyhightower/envconfig"
 	 "github.com/typical-go/typical-go/pkg/typapp"
+	 "github.com/typical-go/typical-rest-server/pkg/typcfg"
 	a "github.com/user/project/pkg"
 )
 
@@ -73,7 +73,7 @@ func init() {
 func LoadSomeSample() (*a.SomeSample, error) {
 	var cfg a.SomeSample
 	prefix := "SOMESAMPLE"
-	if err := envconfig.Process(prefix, &cfg); err != nil {
+	if err := typcfg.Process(prefix, &cfg); err != nil {
 		return nil, fmt.Errorf("%s: %w", prefix, err)
 	}
 	return &cfg, nil
@@ -193,6 +193,50 @@ func TestCfgAnnotation_Annotate_RemoveTargetWhenNoAnnotation(t *testing.T) {
 	require.True(t, os.IsNotExist(err))
 }
 
+func TestCfgAnnotation_Context_FieldInfo(t *testing.T) {
+	path := "some-cfg.go"
+	ioutil.WriteFile(path, []byte(`package mypkg
+
+type SomeCfg struct {
+	// Timeout of
+	// the request
+	Timeout time.Duration `+"`default:\"5s\"`"+`
+	Host    string        `+"`desc:\"some-desc\"`"+` // some-comment
+	Port    string        // some-comment
+}
+`), 0777)
+	defer os.Remove(path)
+
+	c := &typast.Context{
+		Context: &typgo.Context{},
+		Summary: &typast.Summary{
+			Annots: []*typast.Annot{
+				{
+					TagName: "@envconfig",
+					Decl: &typast.Decl{
+						File: typast.File{Package: "mypkg", Path: path},
+						Type: &typast.StructDecl{
+							TypeDecl: typast.TypeDecl{Name: "SomeCfg"},
+							Fields: []*typast.Field{
+								{Names: []string{"Timeout"}, StructTag: `default:"5s"`},
+								{Names: []string{"Host"}, Type: "string", StructTag: `desc:"some-desc"`},
+								{Names: []string{"Port"}, Type: "string"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	context := (&typcfg.EnvconfigAnnotation{}).Context(c)
+	require.Equal(t, []*typcfg.Field{
+		{Key: "SOMECFG_TIMEOUT", Name: "Timeout", Type: "time.Duration", Desc: "Timeout of the request", Default: "5s"},
+		{Key: "SOMECFG_HOST", Name: "Host", Type: "string", Desc: "some-desc"},
+		{Key: "SOMECFG_PORT", Name: "Port", Type: "string", Desc: "some-comment"},
+	}, context.Configs[0].Fields)
+}
+
 func TestCreateField(t *testing.T) {
 	testnames := []struct {
 		TestName string
@@ -203,7 +247,7 @@ func TestCreateField(t *testing.T) {
 		{
 			Prefix:   "APP",
 			Field:    &typast.Field{Names: []string{"Address"}},
-			Expected: &typcfg.Field{Key: "APP_ADDRESS"},
+			Expected: &typcfg.Field{Key: "APP_ADDRESS", Name: "Address"},
 		},
 		{
 			Prefix: "APP",
@@ -211,7 +255,24 @@ func TestCreateField(t *testing.T) {
 				Names:     []string{"some-name"},
 				StructTag: reflect.StructTag(`envconfig:"ADDRESS" default:"some-address" required:"true"`),
 			},
-			Expected: &typcfg.Field{Key: "APP_ADDRESS", Default: "some-address", Required: true},
+			Expected: &typcfg.Field{Key: "APP_ADDRESS", Name: "some-name", Default: "some-address", Required: true},
+		},
+		{
+			Prefix: "DB",
+			Field: &typast.Field{
+				Names:     []string{"Pass"},
+				StructTag: reflect.StructTag(`secret:"true"`),
+			},
+			Expected: &typcfg.Field{Key: "DB_PASS", Name: "Pass", Secret: true},
+		},
+		{
+			Prefix: "DB",
+			Field: &typast.Field{
+				Names:     []string{"Mode"},
+				Type:      "string",
+				StructTag: reflect.StructTag(`desc:"some-desc" validate:"oneof=a b"`),
+			},
+			Expected: &typcfg.Field{Key: "DB_MODE", Name: "Mode", Type: "string", Desc: "some-desc", Validate: "oneof=a b"},
 		},
 	}
 	for _, tt := range testnames {
@@ -7,7 +7,7 @@ import (
 	"github.com/typical-go/typical-go/pkg/envkit"
 )
 
-// GenerateAndLoadDotEnv to create and load envfile
+// GenerateAndLoadDotEnv to create and load envfile then overlay the dotenv profile (if selected)
 func GenerateAndLoadDotEnv(target string, c *Context) error {
 	envmap, err := envkit.ReadFile(target)
 	if err != nil {
@@ -17,6 +17,9 @@ func GenerateAndLoadDotEnv(target string, c *Context) error {
 	var updatedKeys []string
 	for _, Envconfig := range c.Configs {
 		for _, field := range Envconfig.Fields {
+			if field.Secret {
+				continue
+			}
 			if _, ok := envmap[field.Key]; !ok {
 				updatedKeys = append(updatedKeys, field.Key)
 				envmap[field.Key] = field.Default
@@ -31,5 +34,8 @@ func GenerateAndLoadDotEnv(target string, c *Context) error {
 		return err
 	}
 
-	return envkit.Setenv(envmap)
+	if err := envkit.Setenv(envmap); err != nil {
+		return err
+	}
+	return LoadProfile(target)
 }
@@ -25,6 +25,7 @@ func TestCreateAndLoadDotEnv_EnvFileExist(t *testing.T) {
 					{Key: "key1", Default: "val1"},
 					{Key: "key2", Default: "val2"},
 					{Key: "key3", Default: "val3"},
+					{Key: "key4", Default: "val4", Secret: true},
 				},
 			},
 		},
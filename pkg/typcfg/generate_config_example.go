@@ -0,0 +1,33 @@
+package typcfg
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	"github.com/typical-go/typical-go/pkg/typast"
+)
+
+// GenerateConfigExample generate example of config file (YAML) keyed by prefix then field key where secret is commented out
+func GenerateConfigExample(target string, c *Context) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n", typast.Signature{TagName: "@envconfig"})
+	fmt.Fprintf(&b, "# Example of config file. Set the path to `%s` env. The environment variable take precedence\n", ConfigFileEnv)
+	for _, config := range c.Configs {
+		fmt.Fprintf(&b, "\n# %s\n%s:\n", config.Name, config.Prefix)
+		for _, field := range config.Fields {
+			key := strings.TrimPrefix(field.Key, config.Prefix+"_")
+			if field.Desc != "" {
+				fmt.Fprintf(&b, "  # %s\n", field.Desc)
+			}
+			if field.Secret {
+				fmt.Fprintf(&b, "  # %s: %s\n", key, strconv.Quote(secretMask))
+				continue
+			}
+			fmt.Fprintf(&b, "  %s: %s\n", key, strconv.Quote(field.Default))
+		}
+	}
+	fmt.Fprintf(Stdout, "Generate '%s'\n", target)
+	return ioutil.WriteFile(target, []byte(b.String()), 0644)
+}
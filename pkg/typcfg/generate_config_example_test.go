@@ -0,0 +1,57 @@
+package typcfg_test
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/typical-go/typical-rest-server/pkg/typcfg"
+)
+
+func TestGenerateConfigExample(t *testing.T) {
+	var out strings.Builder
+	typcfg.Stdout = &out
+	defer func() { typcfg.Stdout = os.Stdout }()
+
+	target := "sample.example.yaml"
+	defer os.Remove(target)
+
+	c := &typcfg.Context{
+		Configs: []*typcfg.Envconfig{
+			{
+				Name:   "DbCfg",
+				Prefix: "DB",
+				Fields: []*typcfg.Field{
+					{Key: "DB_NAME", Default: "some-name", Desc: "some-desc"},
+					{Key: "DB_PASS", Default: "some-pass", Secret: true},
+				},
+			},
+			{
+				Name:   "AppCfg",
+				Prefix: "APP",
+				Fields: []*typcfg.Field{
+					{Key: "APP_ADDRESS", Default: ":8089"},
+				},
+			},
+		},
+	}
+	require.NoError(t, typcfg.GenerateConfigExample(target, c))
+
+	b, _ := ioutil.ReadFile(target)
+	require.Equal(t, `# DO NOT EDIT. This file generated due to '@envconfig' annotation
+# Example of config file. Set the path to `+"`CONFIG_FILE`"+` env. The environment variable take precedence
+
+# DbCfg
+DB:
+  # some-desc
+  NAME: "some-name"
+  # PASS: "******"
+
+# AppCfg
+APP:
+  ADDRESS: ":8089"
+`, string(b))
+	require.Equal(t, "Generate 'sample.example.yaml'\n", out.String())
+}
@@ -0,0 +1,109 @@
+package typcfg
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+)
+
+type (
+	// JSONSchema of environment variables
+	JSONSchema struct {
+		Schema     string                         `json:"$schema"`
+		Title      string                         `json:"title,omitempty"`
+		Type       string                         `json:"type"`
+		Properties map[string]*JSONSchemaProperty `json:"properties"`
+		Required   []string                       `json:"required,omitempty"`
+	}
+	// JSONSchemaProperty is schema of environment variable
+	JSONSchemaProperty struct {
+		Type        string        `json:"type"`
+		Description string        `json:"description,omitempty"`
+		Default     interface{}   `json:"default,omitempty"`
+		Enum        []interface{} `json:"enum,omitempty"`
+		WriteOnly   bool          `json:"writeOnly,omitempty"` // secret
+		Reload      bool          `json:"x-reload,omitempty"`
+		GoType      string        `json:"x-go-type,omitempty"`
+		Config      string        `json:"x-config"` // e.g. `DatabaseCfg (PG)`
+	}
+)
+
+// GenerateJSONSchema generate JSON Schema of environment variables. Required is the key which required without default value
+func GenerateJSONSchema(target string, c *Context) error {
+	b, err := json.MarshalIndent(CreateJSONSchema(c), "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(Stdout, "Generate '%s'\n", target)
+	return ioutil.WriteFile(target, append(b, '\n'), 0644)
+}
+
+// CreateJSONSchema create JSON Schema of environment variables
+func CreateJSONSchema(c *Context) *JSONSchema {
+	schema := &JSONSchema{
+		Schema:     "http://json-schema.org/draft-07/schema#",
+		Title:      c.BuildSys.ProjectName,
+		Type:       "object",
+		Properties: make(map[string]*JSONSchemaProperty),
+	}
+	for _, config := range c.Configs {
+		for _, field := range config.Fields {
+			typ := jsonType(field.Type)
+			prop := &JSONSchemaProperty{
+				Type:        typ,
+				Description: field.Desc,
+				WriteOnly:   field.Secret,
+				Reload:      field.Reload,
+				GoType:      field.Type,
+				Config:      fmt.Sprintf("%s (%s)", config.Name, config.Prefix),
+			}
+			if !field.Secret && field.Default != "" {
+				prop.Default = jsonValue(typ, field.Default)
+			}
+			for _, rule := range strings.Split(field.Validate, ",") {
+				if strings.HasPrefix(rule, "oneof=") {
+					for _, v := range strings.Fields(strings.TrimPrefix(rule, "oneof=")) {
+						prop.Enum = append(prop.Enum, jsonValue(typ, v))
+					}
+				}
+			}
+			schema.Properties[field.Key] = prop
+			if field.Required && field.Default == "" {
+				schema.Required = append(schema.Required, field.Key)
+			}
+		}
+	}
+	return schema
+}
+
+func jsonType(goType string) string {
+	switch goType {
+	case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64":
+		return "integer"
+	case "float32", "float64":
+		return "number"
+	case "bool":
+		return "boolean"
+	}
+	return "string"
+}
+
+func jsonValue(typ, s string) interface{} {
+	switch typ {
+	case "integer":
+		if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return n
+		}
+	case "number":
+		if n, err := strconv.ParseFloat(s, 64); err == nil {
+			return n
+		}
+	case "boolean":
+		if b, err := strconv.ParseBool(s); err == nil {
+			return b
+		}
+	}
+	return s
+}
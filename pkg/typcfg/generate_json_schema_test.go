@@ -0,0 +1,94 @@
+package typcfg_test
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/typical-go/typical-go/pkg/typast"
+	"github.com/typical-go/typical-go/pkg/typgo"
+	"github.com/typical-go/typical-rest-server/pkg/typcfg"
+)
+
+func TestGenerateJSONSchema(t *testing.T) {
+	var out strings.Builder
+	typcfg.Stdout = &out
+	defer func() { typcfg.Stdout = os.Stdout }()
+
+	target := "sample.schema.json"
+	defer os.Remove(target)
+
+	c := &typcfg.Context{
+		Configs: []*typcfg.Envconfig{
+			{
+				Name:   "DbCfg",
+				Prefix: "DB",
+				Fields: []*typcfg.Field{
+					{Key: "DB_NAME", Type: "string", Required: true, Desc: "some-desc"},
+					{Key: "DB_PASS", Type: "string", Default: "some-pass", Required: true, Secret: true},
+					{Key: "DB_MAX_CONNS", Type: "int", Default: "30", Validate: "min=1"},
+					{Key: "DB_DEBUG", Type: "bool", Default: "false"},
+					{Key: "DB_MODE", Type: "string", Default: "dev", Validate: "required,oneof=dev prod"},
+				},
+			},
+		},
+		Context: &typast.Context{
+			Context: &typgo.Context{
+				BuildSys: &typgo.BuildSys{
+					Descriptor: &typgo.Descriptor{ProjectName: "NAME"},
+				},
+			},
+		},
+	}
+	require.NoError(t, typcfg.GenerateJSONSchema(target, c))
+
+	b, _ := ioutil.ReadFile(target)
+	require.Equal(t, `{
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "title": "NAME",
+  "type": "object",
+  "properties": {
+    "DB_DEBUG": {
+      "type": "boolean",
+      "default": false,
+      "x-go-type": "bool",
+      "x-config": "DbCfg (DB)"
+    },
+    "DB_MAX_CONNS": {
+      "type": "integer",
+      "default": 30,
+      "x-go-type": "int",
+      "x-config": "DbCfg (DB)"
+    },
+    "DB_MODE": {
+      "type": "string",
+      "default": "dev",
+      "enum": [
+        "dev",
+        "prod"
+      ],
+      "x-go-type": "string",
+      "x-config": "DbCfg (DB)"
+    },
+    "DB_NAME": {
+      "type": "string",
+      "description": "some-desc",
+      "x-go-type": "string",
+      "x-config": "DbCfg (DB)"
+    },
+    "DB_PASS": {
+      "type": "string",
+      "writeOnly": true,
+      "x-go-type": "string",
+      "x-config": "DbCfg (DB)"
+    }
+  },
+  "required": [
+    "DB_NAME"
+  ]
+}
+`, string(b))
+	require.Equal(t, "Generate 'sample.schema.json'\n", out.String())
+}
@@ -12,7 +12,7 @@ type (
 	usageTmplData struct {
 		typast.Signature
 		ProjectName string
-		Fields      []*Field
+		Configs     []*Envconfig
 		EnvSnippet  string
 	}
 )
@@ -22,14 +22,20 @@ const usageTmpl = `# {{.ProjectName}}
 <!-- {{.Signature}} -->
 
 ## Configuration List
-| Field Name | Default | Required | 
-|---|---|:---:|{{range $f := .Fields}}
-| {{$f.Key}} | {{$f.Default}} | {{if $f.Required}}Yes{{end}} |{{end}}
+{{range $c := .Configs}}
+### {{$c.Name}} ({{$c.Prefix}})
+| Field Name | Type | Default | Required | Description |
+|---|---|---|:---:|---|{{range $f := $c.Fields}}
+| {{$f.Key}} | {{$f.Type}} | {{$f.UsageDefault}} | {{if $f.Required}}Yes{{end}} | {{$f.UsageDesc}} |{{end}}
+{{end}}
+Secret field (masked as ` + "`" + secretMask + "`" + `) can be set from file by ` + "`<KEY>_FILE`" + ` (e.g. docker/kubernetes secret) or registered ` + "`typcfg.SecretProviders`" + `
 
 ## DotEnv example
 {{.EnvSnippet}}
 `
 
+const secretMask = "******"
+
 // GenerateUsage generate usage document
 func GenerateUsage(target string, c *Context) error {
 	fields := fields(c)
@@ -40,7 +46,7 @@ func GenerateUsage(target string, c *Context) error {
 		usageTmplData{
 			Signature:   typast.Signature{TagName: "@envconfig"},
 			ProjectName: c.BuildSys.ProjectName,
-			Fields:      fields,
+			Configs:     c.Configs,
 			EnvSnippet:  envSnippet(fields),
 		},
 	)
@@ -60,8 +66,24 @@ func envSnippet(fields []*Field) string {
 	var env strings.Builder
 	fmt.Fprintln(&env, "```")
 	for _, field := range fields {
+		if field.Secret {
+			continue
+		}
 		fmt.Fprintf(&env, "%s=%s\n", field.Key, field.Default)
 	}
 	fmt.Fprintln(&env, "```")
 	return env.String()
 }
+
+// UsageDefault return default value for usage documentation where secret is masked
+func (f *Field) UsageDefault() string {
+	if f.Secret {
+		return secretMask
+	}
+	return f.Default
+}
+
+// UsageDesc return description for usage documentation
+func (f *Field) UsageDesc() string {
+	return strings.ReplaceAll(f.Desc, "|", "\\|")
+}
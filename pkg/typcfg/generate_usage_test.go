@@ -22,15 +22,20 @@ func TestGenerateUsage(t *testing.T) {
 	c := &typcfg.Context{
 		Configs: []*typcfg.Envconfig{
 			{
+				Name:   "AppCfg",
+				Prefix: "APP",
 				Fields: []*typcfg.Field{
-					{Key: "APP_NAME", Default: "some-name", Required: true},
-					{Key: "APP_DEBUG", Default: "false", Required: false},
+					{Key: "APP_NAME", Type: "string", Default: "some-name", Required: true, Desc: "some-desc | pipe"},
+					{Key: "APP_DEBUG", Type: "bool", Default: "false", Required: false},
 				},
 			},
 			{
+				Name:   "DbCfg",
+				Prefix: "DB",
 				Fields: []*typcfg.Field{
 					{Key: "DB_HOST", Default: "some-host", Required: false},
 					{Key: "DB_PORT", Default: "some-port", Required: true},
+					{Key: "DB_PASS", Default: "some-pass", Required: true, Secret: true},
 				},
 			},
 		},
@@ -55,12 +60,21 @@ func TestGenerateUsage(t *testing.T) {
 <!-- DO NOT EDIT. This file generated due to '@envconfig' annotation -->
 
 ## Configuration List
-| Field Name | Default | Required | 
-|---|---|:---:|
-| APP_NAME | some-name | Yes |
-| APP_DEBUG | false |  |
-| DB_HOST | some-host |  |
-| DB_PORT | some-port | Yes |
+
+### AppCfg (APP)
+| Field Name | Type | Default | Required | Description |
+|---|---|---|:---:|---|
+| APP_NAME | string | some-name | Yes | some-desc \| pipe |
+| APP_DEBUG | bool | false |  |  |
+
+### DbCfg (DB)
+| Field Name | Type | Default | Required | Description |
+|---|---|---|:---:|---|
+| DB_HOST |  | some-host |  |  |
+| DB_PORT |  | some-port | Yes |  |
+| DB_PASS |  | ****** | Yes |  |
+
+Secret field (masked as `+"`******`"+`) can be set from file by `+"`<KEY>_FILE`"+` (e.g. docker/kubernetes secret) or registered `+"`typcfg.SecretProviders`"+`
 
 ## DotEnv example
 %s
@@ -0,0 +1,290 @@
+package typcfg
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/kelseyhightower/envconfig"
+	"github.com/typical-go/typical-go/pkg/envkit"
+	"github.com/typical-go/typical-go/pkg/errkit"
+	"gopkg.in/yaml.v3"
+)
+
+type (
+	// Loaded is config which loaded by Process
+	Loaded struct {
+		Name    string
+		Prefix  string
+		Spec    interface{}       // pointer of the config struct
+		Sources map[string]string // source of each key
+	}
+	// LoadedDump is dump of loaded config
+	LoadedDump struct {
+		Name   string       `json:"name"`
+		Prefix string       `json:"prefix"`
+		Fields []*FieldDump `json:"fields"`
+	}
+	// FieldDump is dump of config field
+	FieldDump struct {
+		Key    string `json:"key"`
+		Value  string `json:"value"`
+		Source string `json:"source"`
+	}
+)
+
+// Source of config value
+const (
+	SourceEnv            = "env"
+	SourceDefault        = "default"
+	SourceSecretFile     = "file"
+	SourceSecretProvider = "secret-provider"
+)
+
+// DotEnvFile is dotenv to identify the value source
+var DotEnvFile = ".env"
+
+// ConfigFileEnv is environment variable of config file path (YAML or JSON) which keyed by prefix then field key
+// e.g. `{"PG": {"DBNAME": "dbname"}}`. The environment variable take precedence over the config file
+var ConfigFileEnv = "CONFIG_FILE"
+
+var (
+	loaded   []*Loaded
+	loadedMx sync.RWMutex
+	// envconfig only read from environment variable so the value from config file or secret is set temporarily
+	processMx sync.Mutex
+)
+
+// Process the config from environment variable (using envconfig) then config file (see ConfigFileEnv) if available.
+// The `secret:"true"` field is resolved from `KEY` env, then config file, then `KEY_FILE` env (path of file contain the secret),
+// then SecretProviders.
+// The required, parsing and `validate` tag violations are reported at once (see Validate).
+// The config and the source of each value is kept for introspection (see Dump)
+func Process(prefix string, cfg interface{}) error {
+	dotenv := readDotEnv()
+	configFile, fileValues, err := readConfigFile(prefix)
+	if err != nil {
+		return err
+	}
+	sources := make(map[string]string)
+
+	processMx.Lock()
+	defer processMx.Unlock()
+
+	typ := reflect.Indirect(reflect.ValueOf(cfg)).Type()
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		key := envKey(prefix, field)
+		if value, ok := os.LookupEnv(key); ok && value != "" {
+			sources[key] = SourceEnv
+			if file, ok := dotenv[key]; ok && file.value == value {
+				sources[key] = file.name
+			}
+			continue
+		}
+		if value, ok := fileValues[strings.TrimPrefix(key, prefix+"_")]; ok {
+			sources[key] = configFile
+			defer setenv(key, value)()
+			continue
+		}
+		if field.Tag.Get("default") != "" {
+			sources[key] = SourceDefault
+		}
+		if field.Tag.Get("secret") != "true" {
+			continue
+		}
+		secret, source, err := resolveSecret(key)
+		if err != nil {
+			return err
+		}
+		if secret == "" {
+			continue
+		}
+		sources[key] = source
+		defer setenv(key, secret)()
+	}
+	errs, failed := processFields(prefix, cfg)
+	errs = append(errs, validateFields(prefix, cfg, failed)...)
+	if err := invalidConfig(errs); err != nil {
+		return err
+	}
+	setLoaded(&Loaded{
+		Name:    typ.Name(),
+		Prefix:  prefix,
+		Spec:    cfg,
+		Sources: sources,
+	})
+	return nil
+}
+
+// processFields process each field using envconfig to report all required and parsing violations at once
+// (envconfig stop at the first violation). Return the violations and their keys
+func processFields(prefix string, cfg interface{}) (errkit.Errors, map[string]bool) {
+	val := reflect.Indirect(reflect.ValueOf(cfg))
+	typ := val.Type()
+	var errs errkit.Errors
+	failed := make(map[string]bool)
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if field.PkgPath != "" {
+			continue // unexported
+		}
+		spec := reflect.New(reflect.StructOf([]reflect.StructField{
+			{Name: field.Name, Type: field.Type, Tag: field.Tag},
+		}))
+		err := envconfig.Process(prefix, spec.Interface())
+		if err == nil {
+			val.Field(i).Set(spec.Elem().Field(0))
+			continue
+		}
+		key := envKey(prefix, field)
+		failed[key] = true
+		var parseErr *envconfig.ParseError
+		switch {
+		case errors.As(err, &parseErr):
+			errs.Append(fmt.Errorf("%s=%s: must be %s", key, maskSecret(field, parseErr.Value), parseErr.TypeName))
+		case strings.HasPrefix(err.Error(), "required key"):
+			errs.Append(fmt.Errorf("%s=: is required", key))
+		default:
+			errs.Append(fmt.Errorf("%s: %w", key, err))
+		}
+	}
+	return errs, failed
+}
+
+// maskSecret return secretMask for non-empty value of `secret:"true"` field
+func maskSecret(field reflect.StructField, value string) string {
+	if field.Tag.Get("secret") == "true" && value != "" {
+		return secretMask
+	}
+	return value
+}
+
+// setenv set the environment variable and return function to restore its previous value
+func setenv(key, value string) (restore func()) {
+	prev, ok := os.LookupEnv(key)
+	os.Setenv(key, value)
+	return func() {
+		if ok {
+			os.Setenv(key, prev)
+		} else {
+			os.Unsetenv(key)
+		}
+	}
+}
+
+func setLoaded(l *Loaded) {
+	loadedMx.Lock()
+	defer loadedMx.Unlock()
+	for i, prev := range loaded {
+		if prev.Prefix == l.Prefix && prev.Name == l.Name {
+			loaded[i] = l
+			return
+		}
+	}
+	loaded = append(loaded, l)
+}
+
+// Dump loaded config where secret is redacted
+func Dump() []*LoadedDump {
+	loadedMx.RLock()
+	defer loadedMx.RUnlock()
+
+	var dumps []*LoadedDump
+	for _, l := range loaded {
+		val := reflect.Indirect(reflect.ValueOf(l.Spec))
+		typ := val.Type()
+		dump := &LoadedDump{Name: l.Name, Prefix: l.Prefix}
+		for i := 0; i < typ.NumField(); i++ {
+			field := typ.Field(i)
+			if field.PkgPath != "" {
+				continue // unexported
+			}
+			key := envKey(l.Prefix, field)
+			value := maskSecret(field, fmt.Sprint(val.Field(i).Interface()))
+			dump.Fields = append(dump.Fields, &FieldDump{
+				Key:    key,
+				Value:  value,
+				Source: l.Sources[key],
+			})
+		}
+		dumps = append(dumps, dump)
+	}
+	return dumps
+}
+
+// DumpHandler return http handler to dump loaded config as JSON
+func DumpHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		enc.Encode(Dump())
+	})
+}
+
+type dotEnvValue struct {
+	name  string
+	value string
+}
+
+// readDotEnv return value of DotEnvFile and its profile (if selected)
+func readDotEnv() map[string]dotEnvValue {
+	m := make(map[string]dotEnvValue)
+	files := []string{DotEnvFile}
+	if profile := os.Getenv(ProfileEnv); profile != "" {
+		files = append(files, ProfileFile(DotEnvFile, profile))
+	}
+	for _, file := range files {
+		envmap, _ := envkit.ReadFile(file)
+		for k, v := range envmap {
+			m[k] = dotEnvValue{name: file, value: v}
+		}
+	}
+	return m
+}
+
+// readConfigFile return path and values of the prefix from config file (if any)
+func readConfigFile(prefix string) (string, map[string]string, error) {
+	path := os.Getenv(ConfigFileEnv)
+	if path == "" {
+		return "", nil, nil
+	}
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", nil, fmt.Errorf("%s: %w", ConfigFileEnv, err)
+	}
+	var m map[string]map[string]interface{}
+	if err := yaml.Unmarshal(b, &m); err != nil {
+		return "", nil, fmt.Errorf("%s: %s: %w", ConfigFileEnv, path, err)
+	}
+	values := make(map[string]string)
+	for key, v := range m[prefix] {
+		switch v := v.(type) {
+		case nil:
+		case []interface{}:
+			var items []string
+			for _, item := range v {
+				items = append(items, fmt.Sprint(item))
+			}
+			values[key] = strings.Join(items, ",") // envconfig slice
+		case map[string]interface{}:
+			var items []string
+			for k, item := range v {
+				items = append(items, fmt.Sprintf("%s:%v", k, item))
+			}
+			sort.Strings(items)
+			values[key] = strings.Join(items, ",") // envconfig map
+		default:
+			values[key] = fmt.Sprint(v)
+		}
+	}
+	return path, values, nil
+}
@@ -0,0 +1,172 @@
+package typcfg_test
+
+import (
+	"io/ioutil"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/typical-go/typical-rest-server/pkg/typcfg"
+)
+
+type dumpCfg struct {
+	Host    string        `envconfig:"HOST" default:"localhost"`
+	Port    string        `envconfig:"PORT" default:"9999"`
+	Timeout time.Duration `envconfig:"TIMEOUT" default:"5s"`
+	User    string        `envconfig:"USER"`
+	Pass    string        `envconfig:"PASS" secret:"true"`
+	Token   string        `envconfig:"TOKEN" secret:"true"`
+}
+
+func TestDump(t *testing.T) {
+	defer os.Clearenv()
+	typcfg.DotEnvFile = "some-dotenv"
+	defer func() { typcfg.DotEnvFile = ".env" }()
+
+	ioutil.WriteFile("some-dotenv", []byte("DUMP_HOST=some-host\nDUMP_PORT=1234"), 0777)
+	defer os.Remove("some-dotenv")
+	ioutil.WriteFile("some-pass", []byte("some-pass"), 0777)
+	defer os.Remove("some-pass")
+
+	os.Setenv("DUMP_HOST", "some-host")
+	os.Setenv("DUMP_PORT", "5678") // override dotenv
+	os.Setenv("DUMP_PASS_FILE", "some-pass")
+
+	var cfg dumpCfg
+	require.NoError(t, typcfg.Process("DUMP", &cfg))
+	// reload will replace the previous
+	require.NoError(t, typcfg.Process("DUMP", &cfg))
+
+	expected := []*typcfg.LoadedDump{
+		{
+			Name:   "dumpCfg",
+			Prefix: "DUMP",
+			Fields: []*typcfg.FieldDump{
+				{Key: "DUMP_HOST", Value: "some-host", Source: "some-dotenv"},
+				{Key: "DUMP_PORT", Value: "5678", Source: "env"},
+				{Key: "DUMP_TIMEOUT", Value: "5s", Source: "default"},
+				{Key: "DUMP_USER", Value: "", Source: ""},
+				{Key: "DUMP_PASS", Value: "******", Source: "file"},
+				{Key: "DUMP_TOKEN", Value: "", Source: ""},
+			},
+		},
+	}
+	var dumps []*typcfg.LoadedDump
+	for _, dump := range typcfg.Dump() {
+		if dump.Prefix == "DUMP" {
+			dumps = append(dumps, dump)
+		}
+	}
+	require.Equal(t, expected, dumps)
+
+	rec := httptest.NewRecorder()
+	typcfg.DumpHandler().ServeHTTP(rec, httptest.NewRequest("GET", "/", nil))
+	require.Equal(t, "application/json; charset=utf-8", rec.Header().Get("Content-Type"))
+	require.Contains(t, rec.Body.String(), `"key": "DUMP_PASS",
+        "value": "******",
+        "source": "file"`)
+	require.NotContains(t, rec.Body.String(), "some-pass")
+}
+
+type fileCfg struct {
+	Host  string            `envconfig:"HOST" default:"localhost"`
+	Port  string            `envconfig:"PORT" default:"9999"`
+	Tags  []string          `envconfig:"TAGS"`
+	Label map[string]string `envconfig:"LABEL"`
+}
+
+func TestProcess_ConfigFile(t *testing.T) {
+	testcases := []struct {
+		TestName string
+		File     string
+		Content  string
+	}{
+		{
+			TestName: "yaml",
+			File:     "some-config.yaml",
+			Content:  "FILE:\n  HOST: some-host\n  PORT: 1234\n  TAGS: [a, b]\n  LABEL: {k1: v1, k2: v2}\nOTHER:\n  HOST: other-host\n",
+		},
+		{
+			TestName: "json",
+			File:     "some-config.json",
+			Content:  `{"FILE": {"HOST": "some-host", "PORT": 1234, "TAGS": ["a", "b"], "LABEL": {"k1": "v1", "k2": "v2"}}}`,
+		},
+	}
+	for _, tt := range testcases {
+		t.Run(tt.TestName, func(t *testing.T) {
+			defer os.Clearenv()
+			ioutil.WriteFile(tt.File, []byte(tt.Content), 0777)
+			defer os.Remove(tt.File)
+
+			os.Setenv("CONFIG_FILE", tt.File)
+			os.Setenv("FILE_PORT", "5678") // env take precedence
+
+			var cfg fileCfg
+			require.NoError(t, typcfg.Process("FILE", &cfg))
+			require.Equal(t, fileCfg{
+				Host:  "some-host",
+				Port:  "5678",
+				Tags:  []string{"a", "b"},
+				Label: map[string]string{"k1": "v1", "k2": "v2"},
+			}, cfg)
+			_, ok := os.LookupEnv("FILE_HOST")
+			require.False(t, ok)
+
+			for _, dump := range typcfg.Dump() {
+				if dump.Prefix == "FILE" {
+					require.Equal(t, &typcfg.FieldDump{Key: "FILE_HOST", Value: "some-host", Source: tt.File}, dump.Fields[0])
+					require.Equal(t, &typcfg.FieldDump{Key: "FILE_PORT", Value: "5678", Source: "env"}, dump.Fields[1])
+				}
+			}
+		})
+	}
+}
+
+func TestProcess_ConfigFileRestoreEnv(t *testing.T) {
+	defer os.Clearenv()
+	ioutil.WriteFile("some-config.yaml", []byte("FILE:\n  HOST: some-host\n"), 0777)
+	defer os.Remove("some-config.yaml")
+
+	os.Setenv("CONFIG_FILE", "some-config.yaml")
+	os.Setenv("FILE_HOST", "") // empty env is overridden by config file
+
+	var cfg fileCfg
+	require.NoError(t, typcfg.Process("FILE", &cfg))
+	require.Equal(t, "some-host", cfg.Host)
+	host, ok := os.LookupEnv("FILE_HOST")
+	require.True(t, ok)
+	require.Equal(t, "", host)
+}
+
+func TestProcess_ConfigFileError(t *testing.T) {
+	defer os.Clearenv()
+	os.Setenv("CONFIG_FILE", "not-exist.yaml")
+	var cfg fileCfg
+	require.EqualError(t, typcfg.Process("FILE", &cfg), "CONFIG_FILE: open not-exist.yaml: no such file or directory")
+}
+
+type invalidCfg struct {
+	Host    string        `envconfig:"HOST" required:"true"`
+	Port    int           `envconfig:"PORT" validate:"min=1"`
+	Timeout time.Duration `envconfig:"TIMEOUT" default:"5s" validate:"gt=0"`
+	Pass    string        `envconfig:"PASS" secret:"true" validate:"min=8"`
+	Token   int           `envconfig:"TOKEN" secret:"true"`
+}
+
+func TestProcess_Invalid(t *testing.T) {
+	defer os.Clearenv()
+	os.Setenv("INVALID_PORT", "0")
+	os.Setenv("INVALID_TIMEOUT", "five-seconds")
+	os.Setenv("INVALID_PASS", "secret")
+	os.Setenv("INVALID_TOKEN", "some-token")
+
+	var cfg invalidCfg
+	require.EqualError(t, typcfg.Process("INVALID", &cfg), "invalid config:\n"+
+		"INVALID_HOST=: is required\n"+
+		"INVALID_TIMEOUT=five-seconds: must be time.Duration\n"+
+		"INVALID_TOKEN=******: must be int\n"+
+		"INVALID_PORT=0: must be greater than or equal to 1\n"+
+		"INVALID_PASS=******: length must be greater than or equal to 8")
+}
@@ -0,0 +1,203 @@
+package typcfg
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"reflect"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/typical-go/typical-go/pkg/errkit"
+)
+
+type (
+	// Reloader of config
+	Reloader struct {
+		Prefix string
+		Load   func() (interface{}, error) // generated loader
+		Fn     func(interface{})           // called with new instance of config when reloadable field changed
+	}
+)
+
+var (
+	reloaders   []*Reloader
+	reloadMx    sync.Mutex
+	externalEnv map[string]bool // environment variable not from DotEnvFile at the first registration
+)
+
+// OnReload register the generated loader and callback of the config. Use generated `On<Config>Reload` function for typed callback
+func OnReload(prefix string, load func() (interface{}, error), fn func(interface{})) {
+	reloadMx.Lock()
+	defer reloadMx.Unlock()
+	initExternalEnv()
+	reloaders = append(reloaders, &Reloader{Prefix: prefix, Load: load, Fn: fn})
+}
+
+// Reload overlay DotEnvFile (and its profile) to environment variable then re-run the loader of registered config.
+// The environment variable which is not from DotEnvFile take precedence. Only `reload:"true"` field is changed,
+// the callback is called with new instance of config if any of them changed. Change of other field is ignored
+// since it require restart. Config which fail to load keep the previous one and doesn't stop the others
+func Reload() error {
+	reloadMx.Lock()
+	defer reloadMx.Unlock()
+
+	initExternalEnv()
+	for k, v := range readDotEnv() {
+		if !externalEnv[k] {
+			os.Setenv(k, v.value)
+		}
+	}
+
+	var errs errkit.Errors
+	done := make(map[string]bool)
+	for _, r := range reloaders {
+		if done[r.Prefix] {
+			continue
+		}
+		done[r.Prefix] = true
+
+		prev := getLoaded(r.Prefix)
+		next, err := r.Load()
+		if err != nil {
+			if prev != nil {
+				setLoaded(prev) // keep previous config
+			}
+			errs.Append(fmt.Errorf("%s: %w", r.Prefix, err))
+			continue
+		}
+		if prev == nil {
+			continue
+		}
+
+		merged, changed := mergeReload(prev, getLoaded(r.Prefix), next)
+		setLoaded(merged)
+		if !changed {
+			continue
+		}
+		fmt.Fprintf(Stdout, "Reload config '%s'\n", r.Prefix)
+		for _, r2 := range reloaders {
+			if r2.Prefix == r.Prefix {
+				r2.Fn(merged.Spec)
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return errors.New(errs.Join("\n"))
+	}
+	return nil
+}
+
+// initExternalEnv keep the key of environment variable which value is not from DotEnvFile (or its profile)
+func initExternalEnv() {
+	if externalEnv != nil {
+		return
+	}
+	dotenv := readDotEnv()
+	externalEnv = make(map[string]bool)
+	for _, env := range os.Environ() {
+		kv := strings.SplitN(env, "=", 2)
+		if file, ok := dotenv[kv[0]]; !ok || len(kv) < 2 || file.value != kv[1] {
+			externalEnv[kv[0]] = true
+		}
+	}
+}
+
+// Watch reload the config when receive SIGHUP or DotEnvFile (or its profile) modified (checked every interval).
+// Return function to stop watching
+func Watch(interval time.Duration) (stop func()) {
+	sighup := make(chan os.Signal, 1)
+	signal.Notify(sighup, syscall.SIGHUP)
+	ticker := time.NewTicker(interval)
+	done := make(chan struct{})
+
+	go func() {
+		modTime := dotEnvModTime()
+		for {
+			select {
+			case <-done:
+				return
+			case <-sighup:
+			case <-ticker.C:
+				t := dotEnvModTime()
+				if t.Equal(modTime) {
+					continue
+				}
+				modTime = t
+			}
+			if err := Reload(); err != nil {
+				fmt.Fprintf(Stdout, "Reload config: %s\n", err.Error())
+			}
+		}
+	}()
+
+	return func() {
+		signal.Stop(sighup)
+		ticker.Stop()
+		close(done)
+	}
+}
+
+// mergeReload return copy of previous config with reloadable field from next config
+func mergeReload(prev, loaded *Loaded, next interface{}) (*Loaded, bool) {
+	prevVal := reflect.Indirect(reflect.ValueOf(prev.Spec))
+	nextVal := reflect.Indirect(reflect.ValueOf(next))
+	typ := prevVal.Type()
+
+	mergedVal := reflect.New(typ)
+	mergedVal.Elem().Set(prevVal)
+	sources := make(map[string]string)
+	for k, v := range prev.Sources {
+		sources[k] = v
+	}
+
+	var changed bool
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if field.PkgPath != "" || reflect.DeepEqual(prevVal.Field(i).Interface(), nextVal.Field(i).Interface()) {
+			continue
+		}
+		key := envKey(prev.Prefix, field)
+		if field.Tag.Get("reload") != "true" {
+			fmt.Fprintf(Stdout, "Reload config '%s': %s changed but require restart\n", prev.Prefix, key)
+			continue
+		}
+		mergedVal.Elem().Field(i).Set(nextVal.Field(i))
+		sources[key] = loaded.Sources[key]
+		changed = true
+	}
+	return &Loaded{
+		Name:    prev.Name,
+		Prefix:  prev.Prefix,
+		Spec:    mergedVal.Interface(),
+		Sources: sources,
+	}, changed
+}
+
+func getLoaded(prefix string) *Loaded {
+	loadedMx.RLock()
+	defer loadedMx.RUnlock()
+	for _, l := range loaded {
+		if l.Prefix == prefix {
+			return l
+		}
+	}
+	return nil
+}
+
+func dotEnvModTime() time.Time {
+	var modTime time.Time
+	files := []string{DotEnvFile}
+	if profile := os.Getenv(ProfileEnv); profile != "" {
+		files = append(files, ProfileFile(DotEnvFile, profile))
+	}
+	for _, file := range files {
+		if info, err := os.Stat(file); err == nil && info.ModTime().After(modTime) {
+			modTime = info.ModTime()
+		}
+	}
+	return modTime
+}
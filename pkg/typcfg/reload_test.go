@@ -0,0 +1,84 @@
+package typcfg_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/typical-go/typical-rest-server/pkg/typcfg"
+)
+
+type reloadCfg struct {
+	Host   string        `envconfig:"HOST" default:"localhost"`
+	MaxAge time.Duration `envconfig:"MAX_AGE" default:"30s" reload:"true"`
+	Debug  bool          `envconfig:"DEBUG" reload:"true"`
+}
+
+func loadReloadCfg() (*reloadCfg, error) {
+	var cfg reloadCfg
+	if err := typcfg.Process("RELOAD", &cfg); err != nil {
+		return nil, err
+	}
+	if cfg.MaxAge < 0 {
+		return nil, errors.New("negative max-age")
+	}
+	return &cfg, nil
+}
+
+func TestReload(t *testing.T) {
+	defer os.Clearenv()
+	typcfg.DotEnvFile = "some-reload-env"
+	defer func() { typcfg.DotEnvFile = ".env" }()
+	defer os.Remove("some-reload-env")
+
+	var out strings.Builder
+	typcfg.Stdout = &out
+	defer func() { typcfg.Stdout = os.Stdout }()
+
+	os.Setenv("RELOAD_DEBUG", "true") // real environment variable take precedence over dotenv
+
+	var reloaded []*reloadCfg
+	typcfg.OnReload("RELOAD",
+		func() (interface{}, error) { return loadReloadCfg() },
+		func(cfg interface{}) { reloaded = append(reloaded, cfg.(*reloadCfg)) },
+	)
+	var reloadedOther []*reloadCfg
+	typcfg.OnReload("OTHER",
+		func() (interface{}, error) {
+			var cfg reloadCfg
+			return &cfg, typcfg.Process("OTHER", &cfg)
+		},
+		func(cfg interface{}) { reloadedOther = append(reloadedOther, cfg.(*reloadCfg)) },
+	)
+
+	cfg, err := loadReloadCfg()
+	require.NoError(t, err)
+	require.Equal(t, &reloadCfg{Host: "localhost", MaxAge: 30 * time.Second, Debug: true}, cfg)
+	require.NoError(t, typcfg.Process("OTHER", &reloadCfg{}))
+
+	// nothing changed
+	require.NoError(t, typcfg.Reload())
+	require.Empty(t, reloaded)
+
+	// only reloadable field is changed
+	ioutil.WriteFile("some-reload-env", []byte("RELOAD_HOST=some-host\nRELOAD_MAX_AGE=1m\nRELOAD_DEBUG=false"), 0777)
+	require.NoError(t, typcfg.Reload())
+	require.Equal(t, []*reloadCfg{{Host: "localhost", MaxAge: time.Minute, Debug: true}}, reloaded)
+	require.Equal(t, &reloadCfg{Host: "localhost", MaxAge: 30 * time.Second, Debug: true}, cfg) // previous instance is not changed
+	require.Equal(t, "Reload config 'RELOAD': RELOAD_HOST changed but require restart\nReload config 'RELOAD'\n", out.String())
+
+	// invalid config keep the previous and doesn't stop the other config
+	ioutil.WriteFile("some-reload-env", []byte("RELOAD_MAX_AGE=-1m\nOTHER_MAX_AGE=2m"), 0777)
+	require.EqualError(t, typcfg.Reload(), "RELOAD: negative max-age")
+	require.Len(t, reloaded, 1)
+	require.Equal(t, []*reloadCfg{{Host: "localhost", MaxAge: 2 * time.Minute}}, reloadedOther)
+	for _, dump := range typcfg.Dump() {
+		if dump.Prefix == "RELOAD" {
+			require.Equal(t, &typcfg.FieldDump{Key: "RELOAD_MAX_AGE", Value: "1m0s", Source: "some-reload-env"}, dump.Fields[1])
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package typcfg
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+type (
+	// SecretProvider lookup secret value by environment key e.g. `PG_DBPASS`.
+	// Return empty string if the provider doesn't have the secret
+	SecretProvider interface {
+		Secret(key string) (string, error)
+	}
+	// SecretProviderFn function as SecretProvider
+	SecretProviderFn func(key string) (string, error)
+	// MapSecretProvider stand-in secret provider from map (e.g. for testing)
+	MapSecretProvider map[string]string
+	// DirSecretProvider stand-in secret provider from directory where each secret is a file
+	// (e.g. `/run/secrets/pg_dbpass` for docker swarm secret)
+	DirSecretProvider string
+)
+
+// SecretProviders is resolved by Process for `secret:"true"` field in order
+var SecretProviders []SecretProvider
+
+var _ SecretProvider = (SecretProviderFn)(nil)
+var _ SecretProvider = (MapSecretProvider)(nil)
+var _ SecretProvider = DirSecretProvider("")
+
+// resolveSecret return the secret and its source
+func resolveSecret(key string) (string, string, error) {
+	if path := os.Getenv(key + "_FILE"); path != "" {
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return "", "", fmt.Errorf("%s_FILE: %w", key, err)
+		}
+		return strings.TrimRight(string(b), "\r\n"), SourceSecretFile, nil
+	}
+	for _, provider := range SecretProviders {
+		secret, err := provider.Secret(key)
+		if err != nil {
+			return "", "", fmt.Errorf("%s: %w", key, err)
+		}
+		if secret != "" {
+			return secret, SourceSecretProvider, nil
+		}
+	}
+	return "", "", nil
+}
+
+//
+// SecretProviderFn
+//
+
+// Secret of key
+func (f SecretProviderFn) Secret(key string) (string, error) {
+	return f(key)
+}
+
+//
+// MapSecretProvider
+//
+
+// Secret of key
+func (m MapSecretProvider) Secret(key string) (string, error) {
+	return m[key], nil
+}
+
+//
+// DirSecretProvider
+//
+
+// Secret of key from file with lowercase key name in the directory
+func (d DirSecretProvider) Secret(key string) (string, error) {
+	b, err := ioutil.ReadFile(filepath.Join(string(d), strings.ToLower(key)))
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(b), "\r\n"), nil
+}
@@ -0,0 +1,97 @@
+package typcfg_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/typical-go/typical-rest-server/pkg/typcfg"
+)
+
+type secretCfg struct {
+	User string `envconfig:"USER" default:"some-user"`
+	Pass string `envconfig:"PASS" required:"true" secret:"true"`
+}
+
+func TestProcess(t *testing.T) {
+	os.MkdirAll("secrets", 0777)
+	defer os.RemoveAll("secrets")
+	ioutil.WriteFile("secrets/db_pass", []byte("pass-from-file\n"), 0777)
+
+	testcases := []struct {
+		TestName    string
+		Env         map[string]string
+		Providers   []typcfg.SecretProvider
+		Expected    *secretCfg
+		ExpectedErr string
+	}{
+		{
+			TestName: "from env",
+			Env:      map[string]string{"DB_PASS": "pass-from-env", "DB_PASS_FILE": "secrets/db_pass"},
+			Expected: &secretCfg{User: "some-user", Pass: "pass-from-env"},
+		},
+		{
+			TestName:  "from file",
+			Env:       map[string]string{"DB_PASS_FILE": "secrets/db_pass"},
+			Providers: []typcfg.SecretProvider{typcfg.MapSecretProvider{"DB_PASS": "pass-from-map"}},
+			Expected:  &secretCfg{User: "some-user", Pass: "pass-from-file"},
+		},
+		{
+			TestName:    "file not exist",
+			Env:         map[string]string{"DB_PASS_FILE": "secrets/not-exist"},
+			ExpectedErr: "DB_PASS_FILE: open secrets/not-exist: no such file or directory",
+		},
+		{
+			TestName: "from providers in order",
+			Providers: []typcfg.SecretProvider{
+				typcfg.DirSecretProvider("secrets/not-exist"),
+				typcfg.MapSecretProvider{"DB_PASS": "pass-from-map"},
+				typcfg.DirSecretProvider("secrets"),
+			},
+			Expected: &secretCfg{User: "some-user", Pass: "pass-from-map"},
+		},
+		{
+			TestName: "from dir",
+			Providers: []typcfg.SecretProvider{
+				typcfg.DirSecretProvider("secrets"),
+			},
+			Expected: &secretCfg{User: "some-user", Pass: "pass-from-file"},
+		},
+		{
+			TestName: "provider error",
+			Providers: []typcfg.SecretProvider{
+				typcfg.SecretProviderFn(func(key string) (string, error) {
+					return "", errors.New("some-error")
+				}),
+			},
+			ExpectedErr: "DB_PASS: some-error",
+		},
+		{
+			TestName:    "missing secret",
+			ExpectedErr: "invalid config:\nDB_PASS=: is required",
+		},
+	}
+	for _, tt := range testcases {
+		t.Run(tt.TestName, func(t *testing.T) {
+			for k, v := range tt.Env {
+				os.Setenv(k, v)
+			}
+			defer os.Clearenv()
+			typcfg.SecretProviders = tt.Providers
+			defer func() { typcfg.SecretProviders = nil }()
+
+			var cfg secretCfg
+			err := typcfg.Process("DB", &cfg)
+			if tt.ExpectedErr != "" {
+				require.EqualError(t, err, tt.ExpectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.Expected, &cfg)
+			_, ok := os.LookupEnv("DB_PASS")
+			require.Equal(t, tt.Env["DB_PASS"] != "", ok)
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package typcfg
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/typical-go/typical-go/pkg/errkit"
+	"gopkg.in/go-playground/validator.v9"
+)
+
+// Validate the config using `validate` tag (go-playground/validator) and report all violations at once.
+// Violation is reported using environment key e.g. `PG_MAX_IDLE_CONNS=50: must be less than or equal to PG_MAX_OPEN_CONNS`
+// where the value of `secret:"true"` field is masked.
+// Beside the baked-in rules, `hostport` rule is available for `host:port` format (host is optional)
+func Validate(prefix string, cfg interface{}) error {
+	return invalidConfig(validateFields(prefix, cfg, nil))
+}
+
+// invalidConfig return the report of violations or nil if no violation
+func invalidConfig(errs errkit.Errors) error {
+	if len(errs) < 1 {
+		return nil
+	}
+	return fmt.Errorf("invalid config:\n%s", errs.Join("\n"))
+}
+
+// validateFields return violations of `validate` tag except for the skipped keys
+func validateFields(prefix string, cfg interface{}, skip map[string]bool) errkit.Errors {
+	validate := validator.New()
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		return envKey(prefix, field)
+	})
+	validate.RegisterValidation("hostport", isHostPort)
+
+	err := validate.Struct(cfg)
+	if err == nil {
+		return nil
+	}
+	var fieldErrs validator.ValidationErrors
+	if !errors.As(err, &fieldErrs) {
+		return errkit.Errors{err}
+	}
+
+	typ := reflect.Indirect(reflect.ValueOf(cfg)).Type()
+	var errs errkit.Errors
+	for _, fe := range fieldErrs {
+		if skip[fe.Field()] {
+			continue
+		}
+		value := fmt.Sprint(fe.Value())
+		if field, ok := typ.FieldByName(fe.StructField()); ok {
+			value = maskSecret(field, value)
+		}
+		errs.Append(fmt.Errorf("%s=%s: %s", fe.Field(), value, violation(prefix, typ, fe)))
+	}
+	return errs
+}
+
+func envKey(prefix string, field reflect.StructField) string {
+	name := field.Tag.Get("envconfig")
+	if name == "" {
+		name = strings.ToUpper(field.Name)
+	}
+	return fmt.Sprintf("%s_%s", prefix, name)
+}
+
+func violation(prefix string, typ reflect.Type, fe validator.FieldError) string {
+	param := fe.Param()
+	length := ""
+	switch fe.Kind() {
+	case reflect.String, reflect.Slice, reflect.Map, reflect.Array:
+		length = "length "
+	}
+
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "min", "gte":
+		return fmt.Sprintf("%smust be greater than or equal to %s", length, param)
+	case "max", "lte":
+		return fmt.Sprintf("%smust be less than or equal to %s", length, param)
+	case "gt":
+		return fmt.Sprintf("%smust be greater than %s", length, param)
+	case "lt":
+		return fmt.Sprintf("%smust be less than %s", length, param)
+	case "len":
+		return fmt.Sprintf("%smust be %s", length, param)
+	case "oneof":
+		return fmt.Sprintf("must be one of [%s]", param)
+	case "numeric":
+		return "must be numeric"
+	case "url":
+		return "must be a valid URL"
+	case "hostport":
+		return "must be in host:port format"
+	case "eqfield":
+		return "must be equal to " + fieldKey(prefix, typ, param)
+	case "nefield":
+		return "must not be equal to " + fieldKey(prefix, typ, param)
+	case "gtfield":
+		return "must be greater than " + fieldKey(prefix, typ, param)
+	case "gtefield":
+		return "must be greater than or equal to " + fieldKey(prefix, typ, param)
+	case "ltfield":
+		return "must be less than " + fieldKey(prefix, typ, param)
+	case "ltefield":
+		return "must be less than or equal to " + fieldKey(prefix, typ, param)
+	}
+	if param != "" {
+		return fmt.Sprintf("failed on '%s=%s' rule", fe.Tag(), param)
+	}
+	return fmt.Sprintf("failed on '%s' rule", fe.Tag())
+}
+
+func fieldKey(prefix string, typ reflect.Type, name string) string {
+	if field, ok := typ.FieldByName(name); ok {
+		return envKey(prefix, field)
+	}
+	return name
+}
+
+func isHostPort(fl validator.FieldLevel) bool {
+	host, port, err := net.SplitHostPort(fl.Field().String())
+	if err != nil {
+		return false
+	}
+	if host != "" && strings.ContainsAny(host, " /") {
+		return false
+	}
+	n, err := strconv.Atoi(port)
+	return err == nil && n > 0 && n <= 65535
+}
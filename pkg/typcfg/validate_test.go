@@ -0,0 +1,60 @@
+package typcfg_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/typical-go/typical-rest-server/pkg/typcfg"
+)
+
+type validateCfg struct {
+	Address      string `envconfig:"ADDRESS" validate:"hostport"`
+	Mode         string `envconfig:"MODE" validate:"oneof=dev prod"`
+	Endpoint     string `validate:"omitempty,url"`
+	MaxOpenConns int    `envconfig:"MAX_OPEN_CONNS" validate:"min=1"`
+	MaxIdleConns int    `envconfig:"MAX_IDLE_CONNS" validate:"ltefield=MaxOpenConns"`
+	Pass         string `envconfig:"PASS" secret:"true" validate:"omitempty,min=8"`
+}
+
+func TestValidate(t *testing.T) {
+	testcases := []struct {
+		TestName    string
+		Cfg         *validateCfg
+		ExpectedErr string
+	}{
+		{
+			TestName: "valid",
+			Cfg:      &validateCfg{Address: ":8089", Mode: "dev", Endpoint: "http://localhost", MaxOpenConns: 30, MaxIdleConns: 6},
+		},
+		{
+			TestName: "valid with host",
+			Cfg:      &validateCfg{Address: "localhost:6379", Mode: "prod", MaxOpenConns: 1, MaxIdleConns: 1},
+		},
+		{
+			TestName: "report all violations",
+			Cfg:      &validateCfg{Address: "localhost", Mode: "staging", Endpoint: "some-endpoint", MaxOpenConns: 0, MaxIdleConns: 6, Pass: "secret"},
+			ExpectedErr: "invalid config:\n" +
+				"PG_ADDRESS=localhost: must be in host:port format\n" +
+				"PG_MODE=staging: must be one of [dev prod]\n" +
+				"PG_ENDPOINT=some-endpoint: must be a valid URL\n" +
+				"PG_MAX_OPEN_CONNS=0: must be greater than or equal to 1\n" +
+				"PG_MAX_IDLE_CONNS=6: must be less than or equal to PG_MAX_OPEN_CONNS\n" +
+				"PG_PASS=******: length must be greater than or equal to 8",
+		},
+		{
+			TestName:    "invalid port",
+			Cfg:         &validateCfg{Address: ":99999", Mode: "dev", MaxOpenConns: 1},
+			ExpectedErr: "invalid config:\nPG_ADDRESS=:99999: must be in host:port format",
+		},
+	}
+	for _, tt := range testcases {
+		t.Run(tt.TestName, func(t *testing.T) {
+			err := typcfg.Validate("PG", tt.Cfg)
+			if tt.ExpectedErr != "" {
+				require.EqualError(t, err, tt.ExpectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -1,14 +1,23 @@
 package typrepo
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"go/token"
+	"io"
+	"io/ioutil"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
+	"text/template"
 
 	"github.com/typical-go/typical-go/pkg/typgo"
+	"github.com/typical-go/typical-rest-server/pkg/astkit"
 
-	"github.com/typical-go/typical-go/pkg/tmplkit"
+	"github.com/iancoleman/strcase"
+	"github.com/typical-go/typical-go/pkg/errkit"
 	"github.com/typical-go/typical-go/pkg/typast"
 )
 
@@ -16,35 +25,60 @@ type (
 	// EntityAnnotation ...
 	EntityAnnotation struct {
 		TagName string // By default is @entity
+		// Templates is template file per dialect (e.g. "postgres": "tools/tmpl/pg_repo.tmpl")
+		// to override the built-in template. The built-in template is available as `{{template "repo" .}}`
+		// and the template data is Entity
+		Templates map[string]string
 	}
-	// Entity ...
+	// Entity is template data for repository template
 	Entity struct {
-		Name       string
-		Table      string
-		Dialect    string
-		CtorDB     string
-		Target     string
-		Package    string
-		Fields     []*Field
-		Imports    map[string]string
-		PrimaryKey *Field
-	}
-	// Field repo
+		Name        string            // struct name e.g. `Book`
+		Table       string            // table name from `table` param e.g. `books`
+		Dialect     string            // `postgres`, `mysql` or `sqlite`
+		CtorDB      string            // dig name tag for the database e.g. `name:"pg"`
+		CtorName    string            // raw ctor_db e.g. `pg`
+		CtorReplica string            // optional dig name tag for the read replica e.g. `name:"pg_replica" optional:"true"`
+		Target      string            // entity package directory
+		Package     string            // entity package name
+		Fields      []*Field          // all fields in declaration order
+		Imports     map[string]string // import path to alias
+		PrimaryKey  *Field            // single primary key, nil if composite
+		PrimaryKeys []*Field          // all primary key fields
+		UniqueKeys  []*Field          // fields with `unique` option
+		UpsertKey   *Field            // conflict key of Upsert i.e. the only `unique` field, nil if none
+		SoftDelete  *Field            // field with `soft_delete` option, nil if none
+		Version     *Field            // field with `version` option, nil if none
+		TagParam    reflect.StructTag // annotation params e.g. `{{.TagParam.Get "table"}}`
+		PkgPath     string            // import path of entity package
+		PkgName     string            // package name of entity e.g. `postgresdb`
+	}
+	// Field is template data for entity field
 	Field struct {
-		Name         string
-		Type         string
-		Column       string
-		PrimaryKey   bool
-		DefaultValue string
-		SkipUpdate   bool
+		Name         string            // field name e.g. `Title`
+		Type         string            // field type e.g. `*time.Time`
+		Column       string            // column name from `column` tag
+		ColumnType   string            // SQL type override from `column_type` tag
+		PrimaryKey   bool              // `pk` option
+		Auto         bool              // value generated by database
+		DefaultValue string            // value when insert/update e.g. `time.Now()` for `now` option
+		SkipUpdate   bool              // excluded from update
+		Unique       bool              // `unique` option
+		SoftDelete   bool              // `soft_delete` option
+		Version      bool              // `version` option
+		Nullable     bool              // pointer or sql.Null* type
+		StructTag    reflect.StructTag // field tag e.g. `{{.StructTag.Get "json"}}`
 	}
 	fieldOptions []string
 )
 
 const (
-	pkOpt       = "pk"
-	nowOpt      = "now"
-	noUpdateOpt = "no_update"
+	pkOpt         = "pk"
+	nowOpt        = "now"
+	noUpdateOpt   = "no_update"
+	uniqueOpt     = "unique"
+	softDeleteOpt = "soft_delete"
+	autoOpt       = "auto"
+	versionOpt    = "version"
 )
 
 var (
@@ -58,14 +92,18 @@ var (
 
 var _ typast.Annotator = (*EntityAnnotation)(nil)
 
-// Annotate Envconfig to prepare dependency-injection and env-file
+// Annotate entity to generate repository. Return aggregated error of all invalid entities
 func (m *EntityAnnotation) Annotate(c *typast.Context) error {
 	annots, _ := typast.FindAnnot(c, m.getTagName(), typast.EqualStruct)
+	var errs errkit.Errors
 	for _, a := range annots {
 		if err := m.process(a); err != nil {
-			fmt.Fprintf(Stdout, "WARN: Failed process @entity at '%s': %s\n", a.GetName(), err.Error())
+			errs.Append(err)
 		}
 	}
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid %s:\n%s", m.getTagName(), errs.Join("\n"))
+	}
 	return nil
 }
 
@@ -74,27 +112,62 @@ func (m *EntityAnnotation) process(a *typast.Annot2) error {
 	if err != nil {
 		return err
 	}
-	tmpl, err := getTemplate(entity.Dialect)
-	if err != nil {
+	var buf bytes.Buffer
+	if err := m.generate(&buf, entity); err != nil {
 		return err
 	}
 	folder := fmt.Sprintf("internal/generated/%s_repo", entity.Package)
 	os.MkdirAll(folder, 0777)
 	path := fmt.Sprintf("%s/%s_repo.go", folder, strings.ToLower(entity.Name))
 	fmt.Fprintf(Stdout, "Generate repository: %s\n", path)
-	if err := tmplkit.WriteFile(path, tmpl, entity); err != nil {
+	if err := ioutil.WriteFile(path, buf.Bytes(), 0777); err != nil {
 		return err
 	}
 	typgo.GoImports(path)
 	return nil
 }
 
+// generate write the repository code of the entity
+func (m *EntityAnnotation) generate(w io.Writer, entity *Entity) error {
+	tmpl, err := m.getTemplate(entity.Dialect)
+	if err != nil {
+		return err
+	}
+	if err := tmpl.Execute(w, entity); err != nil {
+		return fmt.Errorf("%s: %w", entity.Name, err)
+	}
+	return nil
+}
+
+// getTemplate return the dialect template which is overridden by template file if any
+func (m *EntityAnnotation) getTemplate(dialect string) (*template.Template, error) {
+	builtin, err := getTemplate(dialect)
+	if err != nil {
+		return nil, err
+	}
+	tmpl, err := template.New("repo").Parse(builtin)
+	if err != nil {
+		return nil, err
+	}
+	path, ok := m.Templates[strings.ToLower(dialect)]
+	if !ok {
+		return tmpl, nil
+	}
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return tmpl.New(filepath.Base(path)).Parse(string(b))
+}
+
 func getTemplate(dialect string) (string, error) {
 	switch strings.ToLower(dialect) {
 	case "postgres":
 		return postgresTmpl, nil
 	case "mysql":
 		return mysqlTmpl, nil
+	case "sqlite":
+		return sqliteTmpl, nil
 	}
 	return "", fmt.Errorf("Unknown dialect: %s", dialect)
 }
@@ -121,18 +194,37 @@ func CreateEntity(a *typast.Annot2) (*Entity, error) {
 
 	dialect := a.TagParam.Get("dialect")
 
-	ctorDB := a.TagParam.Get("ctor_db")
+	info, err := astkit.ParseStruct(a.File.Path, name)
+	if err != nil {
+		return nil, err
+	}
+	var errs errkit.Errors
+	if _, err := getTemplate(dialect); err != nil {
+		errs.Append(fmt.Errorf("%s: %s: %w", info.Pos, name, err))
+	}
+
+	ctorName := a.TagParam.Get("ctor_db")
+	ctorDB := ctorName
 	if ctorDB != "" {
 		ctorDB = fmt.Sprintf("`name:\"%s\"`", ctorDB)
 	}
 
+	ctorReplica := "`name:\"replica\" optional:\"true\"`"
+	if ctorName != "" {
+		ctorReplica = fmt.Sprintf("`name:\"%s_replica\" optional:\"true\"`", ctorName)
+	}
+
 	target := a.TagParam.Get("target")
 	if target == "" {
 		target = filepath.Dir(a.Path)
 	}
 
 	var fields []*Field
-	var primaryKey *Field
+	var primaryKeys []*Field
+	var uniqueKeys []*Field
+	var softDelete *Field
+	var version *Field
+	columns := make(map[string]bool)
 	structDecl := a.Decl.Type.(*typast.StructDecl)
 	for _, f := range structDecl.Fields {
 		name := f.Names[0]
@@ -140,27 +232,97 @@ func CreateEntity(a *typast.Annot2) (*Entity, error) {
 		if column == "" {
 			column = strings.ToLower(name)
 		}
-		var opts fieldOptions
-		opts = strings.Split(f.StructTag.Get("option"), ",")
+		opts := parseOptions(f.StructTag.Get("option"))
+
+		typ := f.Type
+		pos := info.Pos
+		if fieldInfo, ok := info.Fields[name]; ok {
+			typ, pos = fieldInfo.Type, fieldInfo.Pos
+		}
+		fieldErr := func(format string, args ...interface{}) {
+			errs.Append(fmt.Errorf("%s: %s.%s: %s", pos, a.GetName(), name, fmt.Sprintf(format, args...)))
+		}
+		if unknown := opts.unknown(); len(unknown) > 0 {
+			fieldErr("unknown option %s", strings.Join(unknown, ", "))
+		}
+		if !isSupportedType(typ) {
+			fieldErr("unsupported field type %s", typ)
+		}
+		if columns[column] {
+			fieldErr("duplicate column %s", column)
+		}
+		columns[column] = true
+		if opts.auto() && !opts.primaryKey() {
+			fieldErr("auto option require pk")
+		}
+		if opts.softDelete() && !isNullable(typ) {
+			fieldErr("soft_delete field must be nullable e.g. *time.Time")
+		}
+		if opts.version() && !isInteger(typ) {
+			fieldErr("version field must be integer")
+		}
+		if opts.unique() && len(uniqueKeys) > 0 {
+			fieldErr("only one unique field is supported (conflict key of Upsert), %s is already unique", uniqueKeys[0].Name)
+		}
 
 		field := &Field{
 			Name:         name,
-			Type:         f.Type,
+			Type:         typ,
 			Column:       column,
+			ColumnType:   f.StructTag.Get("column_type"),
 			PrimaryKey:   opts.primaryKey(),
+			Auto:         opts.primaryKey() && opts.auto(),
 			DefaultValue: opts.defaultValue(),
-			SkipUpdate:   opts.skipUpdate(),
+			SkipUpdate:   opts.skipUpdate() || opts.softDelete() || opts.version(),
+			Unique:       opts.unique(),
+			SoftDelete:   opts.softDelete(),
+			Version:      opts.version(),
+			Nullable:     isNullable(typ),
+			StructTag:    f.StructTag,
 		}
 		fields = append(fields, field)
 		if field.PrimaryKey {
-			primaryKey = field
+			primaryKeys = append(primaryKeys, field)
+		}
+		if field.Unique {
+			uniqueKeys = append(uniqueKeys, field)
+		}
+		if field.SoftDelete {
+			softDelete = field
+		}
+		if field.Version {
+			version = field
+		}
+	}
+
+	var upsertKey *Field
+	if len(uniqueKeys) > 0 {
+		upsertKey = uniqueKeys[0]
+	}
+
+	var primaryKey *Field
+	if len(primaryKeys) == 1 {
+		primaryKey = primaryKeys[0]
+		if isInteger(primaryKey.Type) {
+			primaryKey.Auto = true // serial or auto increment
+		} else if primaryKey.Auto && !strings.EqualFold(dialect, "postgres") {
+			errs.Append(fmt.Errorf("%s: %s.%s: auto primary key must be integer for %s",
+				info.Fields[primaryKey.Name].Pos, name, primaryKey.Name, dialect))
 		}
 	}
+	if len(primaryKeys) < 1 {
+		errs.Append(fmt.Errorf("%s: %s: missing primary key (option:\"pk\")", info.Pos, name))
+	}
+	if len(errs) > 0 {
+		return nil, errors.New(errs.Join("\n"))
+	}
 
 	imports := map[string]string{
 		"context":                         "",
 		"database/sql":                    "",
+		"errors":                          "",
 		"fmt":                             "",
+		"strings":                         "",
 		"time":                            "",
 		"github.com/Masterminds/squirrel": "sq",
 		"github.com/typical-go/typical-rest-server/pkg/sqkit":      "",
@@ -171,46 +333,196 @@ func CreateEntity(a *typast.Annot2) (*Entity, error) {
 	}
 
 	return &Entity{
-		Name:       name,
-		Table:      table,
-		Dialect:    dialect,
-		CtorDB:     ctorDB,
-		Target:     target,
-		Package:    filepath.Base(target),
-		Fields:     fields,
-		PrimaryKey: primaryKey,
-		Imports:    imports,
+		Name:        name,
+		Table:       table,
+		Dialect:     dialect,
+		CtorDB:      ctorDB,
+		CtorName:    ctorName,
+		CtorReplica: ctorReplica,
+		Target:      target,
+		Package:     filepath.Base(target),
+		Fields:      fields,
+		PrimaryKey:  primaryKey,
+		PrimaryKeys: primaryKeys,
+		UniqueKeys:  uniqueKeys,
+		UpsertKey:   upsertKey,
+		SoftDelete:  softDelete,
+		Version:     version,
+		Imports:     imports,
+		TagParam:    a.TagParam,
+		PkgPath:     typgo.ProjectPkg + "/" + filepath.Dir(a.File.Path),
+		PkgName:     a.File.Package,
 	}, nil
 }
 
+// AutoKey return true if the single primary key generated by database
+func (e *Entity) AutoKey() bool {
+	return e.PrimaryKey != nil && e.PrimaryKey.Auto
+}
+
+// KeyType return type of primary key. Composite primary key use int64 for affected row
+func (e *Entity) KeyType() string {
+	if e.PrimaryKey == nil {
+		return "int64"
+	}
+	return e.PrimaryKey.Type
+}
+
+// InvalidKey return the key returned when error occurred
+func (e *Entity) InvalidKey() string {
+	typ := e.KeyType()
+	switch {
+	case isInteger(typ) && !strings.HasPrefix(typ, "uint"):
+		return "-1"
+	case isInteger(typ) || strings.HasPrefix(typ, "float"):
+		return "0"
+	case typ == "string":
+		return `""`
+	case strings.HasPrefix(typ, "*"):
+		return "nil"
+	}
+	return typ + "{}"
+}
+
+// KeyParams return primary key as function parameters e.g. `id int64`
+func (e *Entity) KeyParams() string {
+	var params []string
+	for _, f := range e.PrimaryKeys {
+		params = append(params, f.Param()+" "+f.Type)
+	}
+	return strings.Join(params, ", ")
+}
+
+// KeyTypes return primary key types e.g. `int64, string`
+func (e *Entity) KeyTypes() string {
+	var types []string
+	for _, f := range e.PrimaryKeys {
+		types = append(types, f.Type)
+	}
+	return strings.Join(types, ", ")
+}
+
+// KeyArgs return primary key as function arguments e.g. `id`
+func (e *Entity) KeyArgs() string {
+	var args []string
+	for _, f := range e.PrimaryKeys {
+		args = append(args, f.Param())
+	}
+	return strings.Join(args, ", ")
+}
+
+//
+// Field
+//
+
+// Param return parameter name of the field
+func (f *Field) Param() string {
+	param := strcase.ToLowerCamel(f.Name)
+	if token.IsKeyword(param) {
+		param += "_"
+	}
+	return param
+}
+
+// isSupportedType return false for type which can't be scanned from column e.g. map, chan, func
+func isSupportedType(typ string) bool {
+	typ = strings.TrimPrefix(typ, "*")
+	switch {
+	case typ == "",
+		strings.HasPrefix(typ, "map["),
+		strings.HasPrefix(typ, "chan "),
+		strings.HasPrefix(typ, "<-chan"),
+		strings.HasPrefix(typ, "func("),
+		strings.HasPrefix(typ, "interface{"),
+		strings.HasPrefix(typ, "struct{"):
+		return false
+	case strings.HasPrefix(typ, "["):
+		return strings.HasSuffix(typ, "]byte") || strings.HasSuffix(typ, "]uint8")
+	}
+	return true
+}
+
+func isInteger(typ string) bool {
+	switch typ {
+	case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64":
+		return true
+	}
+	return false
+}
+
+// isNullable return true for pointer and `sql.Null*` type
+func isNullable(typ string) bool {
+	return strings.HasPrefix(typ, "*") || strings.HasPrefix(typ, "sql.Null")
+}
+
 //
 // FieldOption
 //
 
-func (o fieldOptions) primaryKey() bool {
+// parseOptions split the comma separated `option` tag into trimmed lowercase options
+func parseOptions(tag string) fieldOptions {
+	var opts fieldOptions
+	for _, opt := range strings.Split(tag, ",") {
+		if opt = strings.ToLower(strings.TrimSpace(opt)); opt != "" {
+			opts = append(opts, opt)
+		}
+	}
+	return opts
+}
+
+func (o fieldOptions) has(name string) bool {
 	for _, opt := range o {
-		if strings.EqualFold(opt, pkOpt) {
+		if opt == name {
 			return true
 		}
 	}
 	return false
 }
 
+func (o fieldOptions) primaryKey() bool {
+	return o.has(pkOpt)
+}
+
+func (o fieldOptions) unknown() []string {
+	var unknown []string
+	for _, opt := range o {
+		switch opt {
+		case pkOpt, nowOpt, noUpdateOpt, uniqueOpt, softDeleteOpt, autoOpt, versionOpt:
+		default:
+			unknown = append(unknown, opt)
+		}
+	}
+	return unknown
+}
+
+func (o fieldOptions) auto() bool {
+	return o.has(autoOpt)
+}
+
 func (o fieldOptions) defaultValue() string {
 	for _, opt := range o {
-		switch strings.ToLower(opt) {
-		case "now":
+		switch opt {
+		case nowOpt:
 			return "time.Now()"
+		case versionOpt:
+			return "1"
 		}
 	}
 	return ""
 }
 
 func (o fieldOptions) skipUpdate() bool {
-	for _, opt := range o {
-		if strings.EqualFold(opt, noUpdateOpt) {
-			return true
-		}
-	}
-	return false
+	return o.has(noUpdateOpt)
+}
+
+func (o fieldOptions) unique() bool {
+	return o.has(uniqueOpt)
+}
+
+func (o fieldOptions) softDelete() bool {
+	return o.has(softDeleteOpt)
+}
+
+func (o fieldOptions) version() bool {
+	return o.has(versionOpt)
 }
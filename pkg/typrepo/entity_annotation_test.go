@@ -0,0 +1,309 @@
+package typrepo_test
+
+import (
+	"fmt"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/typical-go/typical-go/pkg/typast"
+	"github.com/typical-go/typical-rest-server/pkg/typrepo"
+)
+
+func TestCreateEntity(t *testing.T) {
+	testcases := []struct {
+		testName    string
+		source      string
+		expectedErr string
+	}{
+		{
+			testName: "unknown option",
+			source: `type Book struct {
+	ID    int64  ` + "`option:\"pk, unknown\"`" + `
+	Title string ` + "`option:\"no_updat\"`" + `
+}`,
+			expectedErr: "book.go:5:2: Book.ID: unknown option unknown\n" +
+				"book.go:6:2: Book.Title: unknown option no_updat",
+		},
+		{
+			testName: "unsupported field type",
+			source: `type Book struct {
+	ID   int64             ` + "`option:\"pk\"`" + `
+	Tags map[string]string
+}`,
+			expectedErr: "book.go:6:2: Book.Tags: unsupported field type map[string]string",
+		},
+		{
+			testName: "duplicate column",
+			source: `type Book struct {
+	ID    int64  ` + "`option:\"pk\"`" + `
+	Title string
+	Name  string ` + "`column:\"title\"`" + `
+}`,
+			expectedErr: "book.go:7:2: Book.Name: duplicate column title",
+		},
+		{
+			testName: "auto without pk",
+			source: `type Book struct {
+	ID   int64  ` + "`option:\"pk\"`" + `
+	Code string ` + "`option:\"auto\"`" + `
+}`,
+			expectedErr: "book.go:6:2: Book.Code: auto option require pk",
+		},
+		{
+			testName: "soft_delete not nullable",
+			source: `type Book struct {
+	ID        int64     ` + "`option:\"pk\"`" + `
+	DeletedAt time.Time ` + "`option:\"soft_delete\"`" + `
+}`,
+			expectedErr: "book.go:6:2: Book.DeletedAt: soft_delete field must be nullable e.g. *time.Time",
+		},
+		{
+			testName: "version not integer",
+			source: `type Book struct {
+	ID      int64  ` + "`option:\"pk\"`" + `
+	Version string ` + "`option:\"version\"`" + `
+}`,
+			expectedErr: "book.go:6:2: Book.Version: version field must be integer",
+		},
+		{
+			testName: "multiple unique field",
+			source: `type Book struct {
+	ID    int64  ` + "`option:\"pk\"`" + `
+	ISBN  string ` + "`option:\"unique\"`" + `
+	Title string ` + "`option:\"unique\"`" + `
+}`,
+			expectedErr: "book.go:7:2: Book.Title: only one unique field is supported (conflict key of Upsert), ISBN is already unique",
+		},
+		{
+			testName: "missing primary key",
+			source: `type Book struct {
+	Title string
+}`,
+			expectedErr: `book.go:4:6: Book: missing primary key (option:"pk")`,
+		},
+	}
+	for _, tt := range testcases {
+		t.Run(tt.testName, func(t *testing.T) {
+			annot := compileEntity(t, "postgres", tt.source)
+			_, err := typrepo.CreateEntity(annot)
+			require.Error(t, err)
+			require.Equal(t, tt.expectedErr, strings.ReplaceAll(err.Error(), filepath.Dir(annot.Path)+"/", ""))
+		})
+	}
+}
+
+func TestCreateEntity_Options(t *testing.T) {
+	ent, err := typrepo.CreateEntity(compileEntity(t, "postgres", `type Book struct {
+	ID        int64     `+"`option:\" PK , Auto \"`"+`
+	ISBN      string    `+"`option:\"unique ,no_update\"`"+`
+	UpdatedAt time.Time `+"`option:\"no_update, now\"`"+`
+}`))
+	require.NoError(t, err)
+
+	id, isbn, updatedAt := ent.Fields[0], ent.Fields[1], ent.Fields[2]
+	require.True(t, id.PrimaryKey)
+	require.True(t, id.Auto)
+	require.True(t, isbn.Unique)
+	require.True(t, isbn.SkipUpdate)
+	require.True(t, updatedAt.SkipUpdate)
+	require.Equal(t, "time.Now()", updatedAt.DefaultValue)
+	require.Equal(t, isbn, ent.UpsertKey)
+}
+
+func TestEntityAnnotation_Generate(t *testing.T) {
+	compositePK := `type Book struct {
+	AuthorID int64  ` + "`option:\"pk\"`" + `
+	Code     string ` + "`option:\"pk\"`" + `
+	Title    string
+}`
+	uuidPK := `type Book struct {
+	ID    string ` + "`option:\"pk\"`" + `
+	Title string
+}`
+	testcases := []struct {
+		testName string
+		dialect  string
+		source   string
+		expected []string
+	}{
+		{
+			testName: "postgres composite primary key",
+			dialect:  "postgres",
+			source:   compositePK,
+			expected: []string{
+				"func BookPK(authorID int64, code string) sqkit.Eq {",
+				"FindByPK(context.Context, int64, string) (*",
+				"DeleteByPK(ctx context.Context, authorID int64, code string) (int64, error) {",
+				"Create(context.Context, *",
+				"affectedRow, err := res.RowsAffected()",
+			},
+		},
+		{
+			testName: "mysql composite primary key",
+			dialect:  "mysql",
+			source:   compositePK,
+			expected: []string{
+				"func BookPK(authorID int64, code string) sqkit.Eq {",
+				"DeleteByPK(ctx context.Context, authorID int64, code string) (int64, error) {",
+				"affectedRow, err := res.RowsAffected()",
+			},
+		},
+		{
+			testName: "sqlite composite primary key",
+			dialect:  "sqlite",
+			source:   compositePK,
+			expected: []string{
+				"func BookPK(authorID int64, code string) sqkit.Eq {",
+				"DeleteByPK(ctx context.Context, authorID int64, code string) (int64, error) {",
+				"affectedRow, err := res.RowsAffected()",
+			},
+		},
+		{
+			testName: "postgres uuid primary key",
+			dialect:  "postgres",
+			source:   uuidPK,
+			expected: []string{
+				"func BookPK(id string) sqkit.Eq {",
+				"FindByPK(ctx context.Context, id string) (*",
+				`return "", err`,
+				`fmt.Sprintf("RETURNING \"%s\"", BookTable.ID)`,
+				"var id string",
+			},
+		},
+		{
+			testName: "mysql uuid primary key",
+			dialect:  "mysql",
+			source:   uuidPK,
+			expected: []string{
+				"func BookPK(id string) sqkit.Eq {",
+				"FindByPK(ctx context.Context, id string) (*",
+				`return "", err`,
+				"return ent.ID, err",
+			},
+		},
+		{
+			testName: "sqlite uuid primary key",
+			dialect:  "sqlite",
+			source:   uuidPK,
+			expected: []string{
+				"func BookPK(id string) sqkit.Eq {",
+				"FindByPK(ctx context.Context, id string) (*",
+				`return "", err`,
+				"return ent.ID, err",
+			},
+		},
+	}
+	for _, tt := range testcases {
+		t.Run(tt.testName, func(t *testing.T) {
+			ent, err := typrepo.CreateEntity(compileEntity(t, tt.dialect, tt.source))
+			require.NoError(t, err)
+
+			var out strings.Builder
+			require.NoError(t, (&typrepo.EntityAnnotation{}).Generate(&out, ent))
+			_, err = parser.ParseFile(token.NewFileSet(), "book_repo.go", out.String(), 0)
+			require.NoError(t, err)
+			for _, s := range tt.expected {
+				require.Contains(t, out.String(), s)
+			}
+		})
+	}
+}
+
+func TestEntityAnnotation_GenerateSetKeyBeforeAfterCreate(t *testing.T) {
+	for _, dialect := range []string{"postgres", "mysql", "sqlite"} {
+		t.Run(dialect, func(t *testing.T) {
+			ent, err := typrepo.CreateEntity(compileEntity(t, dialect, `type Book struct {
+	ID   int64  `+"`option:\"pk\"`"+`
+	ISBN string `+"`option:\"unique\"`"+`
+}`))
+			require.NoError(t, err)
+
+			var out strings.Builder
+			require.NoError(t, (&typrepo.EntityAnnotation{}).Generate(&out, ent))
+			var funcs []string
+			for _, fn := range strings.Split(out.String(), "\nfunc ") {
+				i := strings.Index(fn, "sqkit.AfterCreate(ctx, ent)")
+				if i < 0 {
+					continue
+				}
+				funcs = append(funcs, fn[:strings.Index(fn, "(ctx")])
+				require.Contains(t, fn[:i], "ent.ID = id", "the after create hook must see the generated key")
+			}
+			require.Equal(t, []string{"(r *BookRepoImpl) Create", "(r *BookRepoImpl) Upsert"}, funcs)
+		})
+	}
+}
+
+func TestEntityAnnotation_GenerateWithTemplate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "typrepo")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	tmplPath := filepath.Join(dir, "pg_repo.tmpl")
+	require.NoError(t, ioutil.WriteFile(tmplPath, []byte(`{{template "repo" .}}
+// {{.Name}}Custom is added by template file
+func {{.Name}}Custom() {}
+`), 0644))
+
+	ent, err := typrepo.CreateEntity(compileEntity(t, "postgres", `type Book struct {
+	ID    int64 `+"`option:\"pk\"`"+`
+	Title string
+}`))
+	require.NoError(t, err)
+
+	testcases := []struct {
+		testName     string
+		templates    map[string]string
+		expectCustom bool
+		expectedErr  string
+	}{
+		{
+			testName:     "override by template file",
+			templates:    map[string]string{"postgres": tmplPath},
+			expectCustom: true,
+		},
+		{
+			testName:  "fallback to built-in template",
+			templates: map[string]string{"mysql": tmplPath},
+		},
+		{
+			testName:    "missing template file",
+			templates:   map[string]string{"postgres": filepath.Join(dir, "not-found.tmpl")},
+			expectedErr: "open " + filepath.Join(dir, "not-found.tmpl") + ": no such file or directory",
+		},
+	}
+	for _, tt := range testcases {
+		t.Run(tt.testName, func(t *testing.T) {
+			var out strings.Builder
+			err := (&typrepo.EntityAnnotation{Templates: tt.templates}).Generate(&out, ent)
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Contains(t, out.String(), "func NewBookRepo(impl BookRepoImpl) (BookRepo, error) {")
+			require.Equal(t, tt.expectCustom, strings.Contains(out.String(), "func BookCustom() {}"))
+		})
+	}
+}
+
+func compileEntity(t *testing.T, dialect, source string) *typast.Annot2 {
+	dir, err := ioutil.TempDir("", "typrepo")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	path := filepath.Join(dir, "book.go")
+	source = fmt.Sprintf("package mylibrary\n\n// @entity (dialect:%q)\n%s\n", dialect, source)
+	require.NoError(t, ioutil.WriteFile(path, []byte(source), 0644))
+
+	summary, err := typast.Compile(path)
+	require.NoError(t, err)
+	annots := summary.FindAnnot("@entity", typast.EqualStruct)
+	require.Len(t, annots, 1)
+	return &typast.Annot2{Annot: annots[0]}
+}
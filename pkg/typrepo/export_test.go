@@ -0,0 +1,16 @@
+package typrepo
+
+import "io"
+
+// CompatibleType export compatibleType for test
+var CompatibleType = compatibleType
+
+// VerifyColumn export verifyColumn for test
+func VerifyColumn(name string, col *Column, dbType string, dbNullable bool) []string {
+	return verifyColumn(name, col, &dbColumn{Type: dbType, Nullable: dbNullable})
+}
+
+// Generate export generate for test
+func (m *EntityAnnotation) Generate(w io.Writer, entity *Entity) error {
+	return m.generate(w, entity)
+}
@@ -0,0 +1,178 @@
+package typrepo
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// DiffSchema return up and down statements to migrate prev schema to next schema
+func DiffSchema(dialect string, prev, next *Schema) (up, down []string) {
+	dialect = strings.ToLower(dialect)
+	for _, table := range next.Tables {
+		prevTable := prev.Table(table.Name)
+		if prevTable == nil {
+			up = append(up, createTableStmt(dialect, table))
+			down = append([]string{fmt.Sprintf("DROP TABLE IF EXISTS %s;", table.Name)}, down...)
+			continue
+		}
+		tableUp, tableDown := diffTable(dialect, prevTable, table)
+		up = append(up, tableUp...)
+		down = append(tableDown, down...)
+	}
+	for _, prevTable := range prev.Tables {
+		if next.Table(prevTable.Name) == nil {
+			up = append(up, fmt.Sprintf("DROP TABLE IF EXISTS %s;", prevTable.Name))
+			down = append([]string{createTableStmt(dialect, prevTable)}, down...)
+		}
+	}
+	return up, down
+}
+
+func diffTable(dialect string, prev, next *Table) (up, down []string) {
+	for _, col := range next.Columns {
+		prevCol := prev.Column(col.Name)
+		switch {
+		case prevCol == nil:
+			up = append(up, addColumnStmts(dialect, next.Name, col)...)
+			down = append([]string{dropColumnStmt(dialect, next.Name, col.Name)}, down...)
+		case !reflect.DeepEqual(prevCol, col):
+			up = append(up, alterColumnStmts(dialect, next.Name, prevCol, col)...)
+			down = append(alterColumnStmts(dialect, next.Name, col, prevCol), down...)
+		}
+	}
+	for _, prevCol := range prev.Columns {
+		if next.Column(prevCol.Name) == nil {
+			up = append(up, dropColumnStmt(dialect, next.Name, prevCol.Name))
+			down = append(addColumnStmts(dialect, next.Name, prevCol), down...)
+		}
+	}
+	if !reflect.DeepEqual(prev.PrimaryKey, next.PrimaryKey) || !reflect.DeepEqual(prev.Unique, next.Unique) {
+		todo := fmt.Sprintf("-- TODO: change primary key/unique constraint of %s manually", next.Name)
+		up = append(up, todo)
+		down = append([]string{todo}, down...)
+	}
+	return up, down
+}
+
+func createTableStmt(dialect string, t *Table) string {
+	inlinePK := dialect == "sqlite" && len(t.PrimaryKey) == 1 && t.Column(t.PrimaryKey[0]).Auto
+	var defs []string
+	for _, col := range t.Columns {
+		defs = append(defs, columnDef(dialect, col, inlinePK))
+	}
+	if len(t.PrimaryKey) > 0 && !inlinePK {
+		defs = append(defs, fmt.Sprintf("PRIMARY KEY (%s)", strings.Join(t.PrimaryKey, ", ")))
+	}
+	for _, column := range t.Unique {
+		defs = append(defs, fmt.Sprintf("UNIQUE (%s)", column))
+	}
+	return fmt.Sprintf("CREATE TABLE %s (\n    %s\n);", t.Name, strings.Join(defs, ",\n    "))
+}
+
+func columnDef(dialect string, c *Column, inlinePK bool) string {
+	if c.Auto && inlinePK {
+		return fmt.Sprintf("%s %s PRIMARY KEY AUTOINCREMENT", c.Name, c.Type)
+	}
+	def := c.Name + " " + c.Type
+	if c.Nullable {
+		def += " NULL"
+	} else {
+		def += " NOT NULL"
+	}
+	if c.Default != "" {
+		def += " DEFAULT " + c.Default
+	}
+	if c.Auto && dialect == "mysql" {
+		def += " AUTO_INCREMENT"
+	}
+	return def
+}
+
+// addColumnStmts add the column to existing table. The existing rows require a value for NOT NULL column
+// so it is added with zero value default which dropped afterward, or as nullable if the type has no zero value
+func addColumnStmts(dialect, table string, c *Column) []string {
+	if c.Nullable || c.Auto || c.Default != "" {
+		return []string{fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s;", table, columnDef(dialect, c, false))}
+	}
+	col := *c
+	col.Default = zeroDefault(dialect, c.Type)
+	if col.Default == "" {
+		col.Nullable = true
+		return []string{
+			fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s;", table, columnDef(dialect, &col, false)),
+			fmt.Sprintf("-- TODO: fill %s.%s of the existing rows then set NOT NULL", table, c.Name),
+		}
+	}
+	stmts := []string{fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s;", table, columnDef(dialect, &col, false))}
+	if dialect != "sqlite" {
+		stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s DROP DEFAULT;", table, c.Name))
+	}
+	return stmts
+}
+
+// zeroDefault return zero value of the column type as default value, empty if not available e.g. uuid
+func zeroDefault(dialect, typ string) string {
+	switch typeFamily(typ) {
+	case "integer", "float":
+		return "0"
+	case "boolean":
+		return "FALSE"
+	case "time":
+		return "CURRENT_TIMESTAMP"
+	case "string":
+		typ = strings.ToLower(typ)
+		if strings.Contains(typ, "char") || (strings.Contains(typ, "text") && dialect != "mysql") {
+			return "''"
+		}
+	}
+	return ""
+}
+
+func dropColumnStmt(dialect, table, column string) string {
+	if dialect == "sqlite" {
+		return fmt.Sprintf("-- TODO: sqlite can't drop column %s.%s, recreate the table manually", table, column)
+	}
+	return fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s;", table, column)
+}
+
+func alterColumnStmts(dialect, table string, from, to *Column) []string {
+	switch dialect {
+	case "postgres":
+		var stmts []string
+		if from.Type != to.Type {
+			stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s TYPE %s;", table, to.Name, pgAlterType(to.Type)))
+		}
+		if from.Nullable != to.Nullable {
+			action := "SET NOT NULL"
+			if to.Nullable {
+				action = "DROP NOT NULL"
+			}
+			stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s %s;", table, to.Name, action))
+		}
+		if from.Default != to.Default {
+			action := "DROP DEFAULT"
+			if to.Default != "" {
+				action = "SET DEFAULT " + to.Default
+			}
+			stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s %s;", table, to.Name, action))
+		}
+		return stmts
+	case "mysql":
+		return []string{fmt.Sprintf("ALTER TABLE %s MODIFY COLUMN %s;", table, columnDef(dialect, to, false))}
+	}
+	return []string{fmt.Sprintf("-- TODO: %s can't alter column %s.%s to %s, recreate the table manually", dialect, table, to.Name, columnDef(dialect, to, false))}
+}
+
+// pgAlterType return the underlying type of serial type which is only available in create table
+func pgAlterType(typ string) string {
+	switch strings.ToUpper(typ) {
+	case "SMALLSERIAL":
+		return "SMALLINT"
+	case "SERIAL":
+		return "INTEGER"
+	case "BIGSERIAL":
+		return "BIGINT"
+	}
+	return typ
+}
@@ -0,0 +1,172 @@
+package typrepo
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/typical-go/typical-go/pkg/typast"
+	"github.com/typical-go/typical-go/pkg/typgo"
+	"github.com/urfave/cli/v2"
+)
+
+type (
+	// MigrationCmd generate SQL migration by diff the @entity structs against the schema state
+	// (`schema.json`) of latest generated migration
+	MigrationCmd struct {
+		Name    string // By default is "migration"
+		TagName string // By default is "@entity"
+		Dir     string // By default is "databases/%s" where %s is the entity package
+	}
+)
+
+var _ typgo.Cmd = (*MigrationCmd)(nil)
+var _ typgo.Action = (*MigrationCmd)(nil)
+
+var migrationVersion = regexp.MustCompile(`^([0-9]+)_`)
+
+// Command migration
+func (m *MigrationCmd) Command(sys *typgo.BuildSys) *cli.Command {
+	return &cli.Command{
+		Name:      m.getName(),
+		Usage:     "Generate SQL migration from @entity",
+		ArgsUsage: "[name]",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{Name: "baseline", Usage: "Write the schema state without generate migration (for existing migrations)"},
+		},
+		Action: sys.Action(m),
+	}
+}
+
+// Execute migration
+func (m *MigrationCmd) Execute(c *typgo.Context) error {
+	entities, err := LoadEntities(c, m.getTagName())
+	if err != nil {
+		return err
+	}
+	name := c.Args().First()
+	if name == "" {
+		name = "schema"
+	}
+
+	packages, groups := groupByPackage(entities)
+	for _, pkg := range packages {
+		dir := fmt.Sprintf(m.getDir(), pkg)
+		if err := m.generate(dir, name, groups[pkg], c.Bool("baseline")); err != nil {
+			return fmt.Errorf("%s: %w", pkg, err)
+		}
+	}
+	return nil
+}
+
+func (m *MigrationCmd) generate(dir, name string, entities []*Entity, baseline bool) error {
+	dialect := entities[0].Dialect
+	for _, e := range entities {
+		if !strings.EqualFold(e.Dialect, dialect) {
+			return fmt.Errorf("mixed dialect: %s and %s", dialect, e.Dialect)
+		}
+	}
+
+	schemaPath := filepath.Join(dir, "schema.json")
+	prev, err := ReadSchema(schemaPath)
+	if err != nil {
+		return err
+	}
+	next := CreateSchema(entities)
+	if baseline {
+		fmt.Fprintf(Stdout, "Write schema: %s\n", schemaPath)
+		return WriteSchema(schemaPath, next)
+	}
+
+	up, down := DiffSchema(dialect, prev, next)
+	if len(up) < 1 {
+		fmt.Fprintf(Stdout, "No schema change: %s\n", schemaPath)
+		return nil
+	}
+
+	migrationDir := filepath.Join(dir, "migration")
+	os.MkdirAll(migrationDir, 0777)
+	version, err := nextVersion(migrationDir)
+	if err != nil {
+		return err
+	}
+	prefix := filepath.Join(migrationDir, fmt.Sprintf("%d_%s", version, name))
+	for path, stmts := range map[string][]string{prefix + ".up.sql": up, prefix + ".down.sql": down} {
+		fmt.Fprintf(Stdout, "Generate migration: %s\n", path)
+		if err := ioutil.WriteFile(path, []byte(strings.Join(stmts, "\n")+"\n"), 0644); err != nil {
+			return err
+		}
+	}
+	return WriteSchema(schemaPath, next)
+}
+
+func (m *MigrationCmd) getName() string {
+	if m.Name == "" {
+		m.Name = "migration"
+	}
+	return m.Name
+}
+
+func (m *MigrationCmd) getTagName() string {
+	if m.TagName == "" {
+		m.TagName = "@entity"
+	}
+	return m.TagName
+}
+
+func (m *MigrationCmd) getDir() string {
+	if m.Dir == "" {
+		m.Dir = "databases/%s"
+	}
+	return m.Dir
+}
+
+// LoadEntities load entities from the project
+func LoadEntities(c *typgo.Context, tagName string) ([]*Entity, error) {
+	ac, err := (&typast.AnnotateProject{}).CreateContext(c)
+	if err != nil {
+		return nil, err
+	}
+	annots, _ := typast.FindAnnot(ac, tagName, typast.EqualStruct)
+	var entities []*Entity
+	for _, a := range annots {
+		entity, err := CreateEntity(a)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", a.GetName(), err)
+		}
+		entities = append(entities, entity)
+	}
+	return entities, nil
+}
+
+func groupByPackage(entities []*Entity) ([]string, map[string][]*Entity) {
+	var packages []string
+	groups := make(map[string][]*Entity)
+	for _, e := range entities {
+		if _, ok := groups[e.Package]; !ok {
+			packages = append(packages, e.Package)
+		}
+		groups[e.Package] = append(groups[e.Package], e)
+	}
+	return packages, groups
+}
+
+func nextVersion(dir string) (int, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return -1, err
+	}
+	max := 0
+	for _, f := range files {
+		if match := migrationVersion.FindStringSubmatch(f.Name()); match != nil {
+			if v, _ := strconv.Atoi(match[1]); v > max {
+				max = v
+			}
+		}
+	}
+	return max + 1, nil
+}
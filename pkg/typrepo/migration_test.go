@@ -0,0 +1,220 @@
+package typrepo_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/typical-go/typical-rest-server/pkg/typrepo"
+)
+
+func TestDiffSchema(t *testing.T) {
+	testcases := []struct {
+		testName     string
+		dialect      string
+		prev         *typrepo.Schema
+		next         *typrepo.Schema
+		expectedUp   []string
+		expectedDown []string
+	}{
+		{
+			testName: "no change",
+			dialect:  "postgres",
+			prev:     &typrepo.Schema{Tables: []*typrepo.Table{{Name: "books", Columns: []*typrepo.Column{{Name: "id", Type: "BIGSERIAL", Auto: true}}}}},
+			next:     &typrepo.Schema{Tables: []*typrepo.Table{{Name: "books", Columns: []*typrepo.Column{{Name: "id", Type: "BIGSERIAL", Auto: true}}}}},
+		},
+		{
+			testName: "create table with unique columns",
+			dialect:  "postgres",
+			prev:     &typrepo.Schema{},
+			next: &typrepo.Schema{Tables: []*typrepo.Table{
+				{
+					Name: "books",
+					Columns: []*typrepo.Column{
+						{Name: "id", Type: "BIGSERIAL", Auto: true},
+						{Name: "isbn", Type: "VARCHAR(255)"},
+						{Name: "title", Type: "VARCHAR(255)"},
+						{Name: "deleted_at", Type: "TIMESTAMP", Nullable: true},
+					},
+					PrimaryKey: []string{"id"},
+					Unique:     []string{"isbn", "title"},
+				},
+			}},
+			expectedUp: []string{
+				"CREATE TABLE books (\n" +
+					"    id BIGSERIAL NOT NULL,\n" +
+					"    isbn VARCHAR(255) NOT NULL,\n" +
+					"    title VARCHAR(255) NOT NULL,\n" +
+					"    deleted_at TIMESTAMP NULL,\n" +
+					"    PRIMARY KEY (id),\n" +
+					"    UNIQUE (isbn),\n" +
+					"    UNIQUE (title)\n" +
+					");",
+			},
+			expectedDown: []string{"DROP TABLE IF EXISTS books;"},
+		},
+		{
+			testName: "create table in mysql",
+			dialect:  "mysql",
+			prev:     &typrepo.Schema{},
+			next: &typrepo.Schema{Tables: []*typrepo.Table{
+				{
+					Name:       "songs",
+					Columns:    []*typrepo.Column{{Name: "id", Type: "BIGINT", Auto: true}},
+					PrimaryKey: []string{"id"},
+				},
+			}},
+			expectedUp:   []string{"CREATE TABLE songs (\n    id BIGINT NOT NULL AUTO_INCREMENT,\n    PRIMARY KEY (id)\n);"},
+			expectedDown: []string{"DROP TABLE IF EXISTS songs;"},
+		},
+		{
+			testName: "create table in sqlite",
+			dialect:  "sqlite",
+			prev:     &typrepo.Schema{},
+			next: &typrepo.Schema{Tables: []*typrepo.Table{
+				{
+					Name: "movies",
+					Columns: []*typrepo.Column{
+						{Name: "id", Type: "INTEGER", Auto: true},
+						{Name: "updated_at", Type: "TIMESTAMP", Default: "CURRENT_TIMESTAMP"},
+					},
+					PrimaryKey: []string{"id"},
+				},
+			}},
+			expectedUp: []string{
+				"CREATE TABLE movies (\n" +
+					"    id INTEGER PRIMARY KEY AUTOINCREMENT,\n" +
+					"    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP\n" +
+					");",
+			},
+			expectedDown: []string{"DROP TABLE IF EXISTS movies;"},
+		},
+		{
+			testName: "drop table",
+			dialect:  "postgres",
+			prev: &typrepo.Schema{Tables: []*typrepo.Table{
+				{Name: "books", Columns: []*typrepo.Column{{Name: "id", Type: "BIGSERIAL", Auto: true}}, PrimaryKey: []string{"id"}},
+			}},
+			next:         &typrepo.Schema{},
+			expectedUp:   []string{"DROP TABLE IF EXISTS books;"},
+			expectedDown: []string{"CREATE TABLE books (\n    id BIGSERIAL NOT NULL,\n    PRIMARY KEY (id)\n);"},
+		},
+		{
+			testName: "add column to existing rows",
+			dialect:  "postgres",
+			prev: &typrepo.Schema{Tables: []*typrepo.Table{
+				{Name: "books", Columns: []*typrepo.Column{{Name: "id", Type: "BIGSERIAL", Auto: true}}},
+			}},
+			next: &typrepo.Schema{Tables: []*typrepo.Table{
+				{Name: "books", Columns: []*typrepo.Column{
+					{Name: "id", Type: "BIGSERIAL", Auto: true},
+					{Name: "title", Type: "VARCHAR(255)"},
+					{Name: "deleted_at", Type: "TIMESTAMP", Nullable: true},
+					{Name: "version", Type: "BIGINT", Default: "1"},
+					{Name: "ref", Type: "UUID"},
+				}},
+			}},
+			expectedUp: []string{
+				"ALTER TABLE books ADD COLUMN title VARCHAR(255) NOT NULL DEFAULT '';",
+				"ALTER TABLE books ALTER COLUMN title DROP DEFAULT;",
+				"ALTER TABLE books ADD COLUMN deleted_at TIMESTAMP NULL;",
+				"ALTER TABLE books ADD COLUMN version BIGINT NOT NULL DEFAULT 1;",
+				"ALTER TABLE books ADD COLUMN ref UUID NULL;",
+				"-- TODO: fill books.ref of the existing rows then set NOT NULL",
+			},
+			expectedDown: []string{
+				"ALTER TABLE books DROP COLUMN ref;",
+				"ALTER TABLE books DROP COLUMN version;",
+				"ALTER TABLE books DROP COLUMN deleted_at;",
+				"ALTER TABLE books DROP COLUMN title;",
+			},
+		},
+		{
+			testName: "add column in sqlite",
+			dialect:  "sqlite",
+			prev: &typrepo.Schema{Tables: []*typrepo.Table{
+				{Name: "movies", Columns: []*typrepo.Column{{Name: "id", Type: "INTEGER", Auto: true}}},
+			}},
+			next: &typrepo.Schema{Tables: []*typrepo.Table{
+				{Name: "movies", Columns: []*typrepo.Column{
+					{Name: "id", Type: "INTEGER", Auto: true},
+					{Name: "rating", Type: "INTEGER"},
+				}},
+			}},
+			expectedUp:   []string{"ALTER TABLE movies ADD COLUMN rating INTEGER NOT NULL DEFAULT 0;"},
+			expectedDown: []string{"-- TODO: sqlite can't drop column movies.rating, recreate the table manually"},
+		},
+		{
+			testName: "drop column",
+			dialect:  "mysql",
+			prev: &typrepo.Schema{Tables: []*typrepo.Table{
+				{Name: "songs", Columns: []*typrepo.Column{
+					{Name: "id", Type: "BIGINT", Auto: true},
+					{Name: "title", Type: "VARCHAR(255)"},
+				}},
+			}},
+			next: &typrepo.Schema{Tables: []*typrepo.Table{
+				{Name: "songs", Columns: []*typrepo.Column{{Name: "id", Type: "BIGINT", Auto: true}}},
+			}},
+			expectedUp: []string{"ALTER TABLE songs DROP COLUMN title;"},
+			expectedDown: []string{
+				"ALTER TABLE songs ADD COLUMN title VARCHAR(255) NOT NULL DEFAULT '';",
+				"ALTER TABLE songs ALTER COLUMN title DROP DEFAULT;",
+			},
+		},
+		{
+			testName: "alter column in postgres",
+			dialect:  "postgres",
+			prev: &typrepo.Schema{Tables: []*typrepo.Table{
+				{Name: "books", Columns: []*typrepo.Column{
+					{Name: "id", Type: "SERIAL", Auto: true},
+					{Name: "title", Type: "VARCHAR(255)", Nullable: true},
+				}},
+			}},
+			next: &typrepo.Schema{Tables: []*typrepo.Table{
+				{Name: "books", Columns: []*typrepo.Column{
+					{Name: "id", Type: "BIGSERIAL", Auto: true},
+					{Name: "title", Type: "VARCHAR(255)"},
+				}},
+			}},
+			expectedUp: []string{
+				"ALTER TABLE books ALTER COLUMN id TYPE BIGINT;",
+				"ALTER TABLE books ALTER COLUMN title SET NOT NULL;",
+			},
+			expectedDown: []string{
+				"ALTER TABLE books ALTER COLUMN title DROP NOT NULL;",
+				"ALTER TABLE books ALTER COLUMN id TYPE INTEGER;",
+			},
+		},
+		{
+			testName: "alter column in mysql",
+			dialect:  "mysql",
+			prev: &typrepo.Schema{Tables: []*typrepo.Table{
+				{Name: "songs", Columns: []*typrepo.Column{{Name: "id", Type: "BIGINT UNSIGNED", Auto: true}}},
+			}},
+			next: &typrepo.Schema{Tables: []*typrepo.Table{
+				{Name: "songs", Columns: []*typrepo.Column{{Name: "id", Type: "BIGINT", Auto: true}}},
+			}},
+			expectedUp:   []string{"ALTER TABLE songs MODIFY COLUMN id BIGINT NOT NULL AUTO_INCREMENT;"},
+			expectedDown: []string{"ALTER TABLE songs MODIFY COLUMN id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT;"},
+		},
+		{
+			testName: "change unique constraint",
+			dialect:  "postgres",
+			prev: &typrepo.Schema{Tables: []*typrepo.Table{
+				{Name: "books", Columns: []*typrepo.Column{{Name: "isbn", Type: "VARCHAR(255)"}}},
+			}},
+			next: &typrepo.Schema{Tables: []*typrepo.Table{
+				{Name: "books", Columns: []*typrepo.Column{{Name: "isbn", Type: "VARCHAR(255)"}}, Unique: []string{"isbn"}},
+			}},
+			expectedUp:   []string{"-- TODO: change primary key/unique constraint of books manually"},
+			expectedDown: []string{"-- TODO: change primary key/unique constraint of books manually"},
+		},
+	}
+	for _, tt := range testcases {
+		t.Run(tt.testName, func(t *testing.T) {
+			up, down := typrepo.DiffSchema(tt.dialect, tt.prev, tt.next)
+			require.Equal(t, tt.expectedUp, up)
+			require.Equal(t, tt.expectedDown, down)
+		})
+	}
+}
@@ -0,0 +1,251 @@
+package typrepo
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/iancoleman/strcase"
+	"github.com/typical-go/typical-go/pkg/tmplkit"
+	"github.com/typical-go/typical-go/pkg/typgo"
+	"github.com/urfave/cli/v2"
+)
+
+type (
+	// ScaffoldCmd generate service, controller, router and their tests of @entity struct
+	// following the domain pattern (e.g. `internal/app/domain/mylibrary`)
+	ScaffoldCmd struct {
+		Name    string // By default is "scaffold"
+		TagName string // By default is "@entity"
+		Dir     string // By default is "internal/app/domain/%s" where %s is the domain
+	}
+	// Scaffold is template data for scaffold template
+	Scaffold struct {
+		*Entity
+		Domain       string   // domain package e.g. `mylibrary`
+		DomainPkg    string   // import path of domain package
+		RepoPkg      string   // import path of generated repository
+		Var          string   // variable name e.g. `book`
+		Plural       string   // e.g. `Books`
+		VarPlural    string   // e.g. `books`
+		Resource     string   // route path e.g. `books`
+		ParseKey     string   // statement to parse `paramID` to `id`
+		SampleKey    string   // primary key value of `"1"` param e.g. `int64(1)`
+		Sample       string   // valid entity fields e.g. `Title: "some-title"`
+		SampleJSON   string   // JSON body of the sample
+		SampleCols   []string // columns of the sample
+		ValidErrs    []string // validation error of empty entity
+		LocationVerb string   // format verb of primary key in Location header
+	}
+)
+
+var _ typgo.Cmd = (*ScaffoldCmd)(nil)
+var _ typgo.Action = (*ScaffoldCmd)(nil)
+
+// Command scaffold
+func (m *ScaffoldCmd) Command(sys *typgo.BuildSys) *cli.Command {
+	return &cli.Command{
+		Name:      m.getName(),
+		Usage:     "Generate service, controller, router and tests for @entity",
+		ArgsUsage: "[entity] [domain]",
+		Action:    sys.Action(m),
+	}
+}
+
+// Execute scaffold
+func (m *ScaffoldCmd) Execute(c *typgo.Context) error {
+	name, domain := c.Args().Get(0), c.Args().Get(1)
+	if name == "" || domain == "" {
+		return errors.New("missing entity or domain e.g. `scaffold Book mylibrary`")
+	}
+	entities, err := LoadEntities(c, m.getTagName())
+	if err != nil {
+		return err
+	}
+	var entity *Entity
+	for _, e := range entities {
+		if e.Name == name {
+			entity = e
+		}
+	}
+	if entity == nil {
+		return fmt.Errorf("%s: no %s struct", name, m.getTagName())
+	}
+
+	dir := fmt.Sprintf(m.getDir(), domain)
+	data, err := CreateScaffold(entity, domain, typgo.ProjectPkg+"/"+dir)
+	if err != nil {
+		return err
+	}
+	file := strcase.ToSnake(entity.Name)
+	files := []struct {
+		path string
+		tmpl string
+	}{
+		{path: filepath.Join(dir, "service", file+"_svc.go"), tmpl: scaffoldSvcTmpl},
+		{path: filepath.Join(dir, "service", file+"_svc_test.go"), tmpl: scaffoldSvcTestTmpl},
+		{path: filepath.Join(dir, "controller", file+"_cntrl.go"), tmpl: scaffoldCntrlTmpl},
+		{path: filepath.Join(dir, "controller", file+"_cntrl_test.go"), tmpl: scaffoldCntrlTestTmpl},
+		{path: filepath.Join(dir, "router.go"), tmpl: scaffoldRouterTmpl},
+		{path: filepath.Join(dir, "router_test.go"), tmpl: scaffoldRouterTestTmpl},
+	}
+	for _, f := range files {
+		if _, err := os.Stat(f.path); err == nil {
+			fmt.Fprintf(Stdout, "Skip existing file: %s\n", f.path)
+			continue
+		}
+		os.MkdirAll(filepath.Dir(f.path), 0777)
+		fmt.Fprintf(Stdout, "Generate scaffold: %s\n", f.path)
+		if err := tmplkit.WriteFile(f.path, f.tmpl, data); err != nil {
+			return err
+		}
+		typgo.GoImports(f.path)
+	}
+	fmt.Fprintf(Stdout, "\nNext steps:\n"+
+		"- Register %sCntrl in %s.Router if router.go was skipped\n"+
+		"- Register %s.Router to the app in internal/app/start.go\n"+
+		"- Run `./typicalw mock` to generate service_mock\n", entity.Name, domain, domain)
+	return nil
+}
+
+// CreateScaffold create scaffold template data for the entity
+func CreateScaffold(entity *Entity, domain, domainPkg string) (*Scaffold, error) {
+	if entity.PrimaryKey == nil {
+		return nil, fmt.Errorf("%s: scaffold not support composite primary key", entity.Name)
+	}
+	data := &Scaffold{
+		Entity:       entity,
+		Domain:       domain,
+		DomainPkg:    domainPkg,
+		RepoPkg:      fmt.Sprintf("%s/internal/generated/%s_repo", typgo.ProjectPkg, entity.Package),
+		Var:          strcase.ToLowerCamel(entity.Name),
+		Plural:       strcase.ToCamel(entity.Table),
+		VarPlural:    strcase.ToLowerCamel(entity.Table),
+		Resource:     entity.Table,
+		LocationVerb: "%d",
+	}
+	switch typ := entity.PrimaryKey.Type; {
+	case typ == "int64":
+		data.ParseKey = "id, _ := strconv.ParseInt(paramID, 10, 64)"
+		data.SampleKey = "int64(1)"
+	case isInteger(typ):
+		data.ParseKey = fmt.Sprintf("n, _ := strconv.ParseInt(paramID, 10, 64)\n\tid := %s(n)", typ)
+		data.SampleKey = fmt.Sprintf("%s(1)", typ)
+	case typ == "string":
+		data.ParseKey = "id := paramID"
+		data.SampleKey = `"1"`
+		data.LocationVerb = "%s"
+	default:
+		return nil, fmt.Errorf("%s: scaffold not support %s primary key", entity.Name, typ)
+	}
+
+	var required, optional []*Field
+	for _, f := range entity.Fields {
+		validate := f.StructTag.Get("validate")
+		if strings.HasPrefix(validate, "required") {
+			if sampleValue(f.Type) == "" {
+				return nil, fmt.Errorf("%s.%s: scaffold not support required %s field", entity.Name, f.Name, f.Type)
+			}
+			required = append(required, f)
+			data.ValidErrs = append(data.ValidErrs, fmt.Sprintf(
+				"Key: '%s.%s' Error:Field validation for '%s' failed on the 'required' tag",
+				entity.Name, f.Name, f.Name))
+		} else if !f.PrimaryKey && !f.SkipUpdate && f.DefaultValue == "" && validate == "" &&
+			sampleValue(f.Type) != "" {
+			optional = append(optional, f)
+		}
+	}
+	samples := required
+	if len(samples) < 1 {
+		samples = optional
+	}
+
+	var fields, values []string
+	keys := make(map[string]string)
+	for _, f := range samples {
+		key := jsonKey(f)
+		if key == "" {
+			continue
+		}
+		value := sampleValue(f.Type)
+		if value == `""` {
+			value = fmt.Sprintf("%q", "some-"+key)
+		}
+		fields = append(fields, fmt.Sprintf("%s: %s", f.Name, value))
+		keys[key] = value
+		data.SampleCols = append(data.SampleCols, f.Column)
+	}
+	var sortedKeys []string
+	for key := range keys {
+		sortedKeys = append(sortedKeys, key)
+	}
+	sort.Strings(sortedKeys)
+	for _, key := range sortedKeys {
+		values = append(values, fmt.Sprintf("%q:%s", key, keys[key]))
+	}
+	data.Sample = strings.Join(fields, ", ")
+	data.SampleJSON = "{" + strings.Join(values, ",") + "}"
+	return data, nil
+}
+
+// SampleColumns return the sample columns as go arguments e.g. `"title", "author"`
+func (s *Scaffold) SampleColumns() string {
+	var args []string
+	for _, c := range s.SampleCols {
+		args = append(args, fmt.Sprintf("%q", c))
+	}
+	return strings.Join(args, ", ")
+}
+
+// ValidErr return validation error message of empty entity
+func (s *Scaffold) ValidErr() string {
+	return fmt.Sprintf("%q", "code=422, message="+strings.Join(s.ValidErrs, "\n"))
+}
+
+// sampleValue return go literal (which is also valid JSON) of the type, empty if not supported
+func sampleValue(typ string) string {
+	switch {
+	case typ == "string":
+		return `""`
+	case isInteger(typ), typ == "float32", typ == "float64":
+		return "1"
+	case typ == "bool":
+		return "true"
+	}
+	return ""
+}
+
+func jsonKey(f *Field) string {
+	key := strings.Split(f.StructTag.Get("json"), ",")[0]
+	if key == "-" {
+		return ""
+	}
+	if key == "" {
+		return f.Name
+	}
+	return key
+}
+
+func (m *ScaffoldCmd) getName() string {
+	if m.Name == "" {
+		m.Name = "scaffold"
+	}
+	return m.Name
+}
+
+func (m *ScaffoldCmd) getTagName() string {
+	if m.TagName == "" {
+		m.TagName = "@entity"
+	}
+	return m.TagName
+}
+
+func (m *ScaffoldCmd) getDir() string {
+	if m.Dir == "" {
+		m.Dir = "internal/app/domain/%s"
+	}
+	return m.Dir
+}
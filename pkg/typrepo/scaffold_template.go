@@ -0,0 +1,1299 @@
+package typrepo
+
+const scaffoldSvcTmpl = `package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"{{.PkgPath}}"
+	"{{.RepoPkg}}"
+	"github.com/typical-go/typical-rest-server/pkg/dbtxn"
+	"github.com/typical-go/typical-rest-server/pkg/echokit"
+	"github.com/typical-go/typical-rest-server/pkg/sqkit"
+	"go.uber.org/dig"
+	"gopkg.in/go-playground/validator.v9"
+)
+
+type (
+	// {{.Name}}Svc contain logic for {{.Name}} Controller
+	// @mock
+	{{.Name}}Svc interface {
+		FindOne(context.Context, string) (*{{.PkgName}}.{{.Name}}, error)
+		Find(context.Context, *Find{{.Name}}Req) (*Find{{.Name}}Resp, error)
+		Create(context.Context, *{{.PkgName}}.{{.Name}}) (*{{.PkgName}}.{{.Name}}, error)
+		Delete(context.Context, string{{if .Version}}, {{.Version.Type}}{{end}}) error
+		Update(context.Context, string, *{{.PkgName}}.{{.Name}}) (*{{.PkgName}}.{{.Name}}, error)
+		Patch(context.Context, string, *{{.PkgName}}.{{.Name}}, ...string) (*{{.PkgName}}.{{.Name}}, error)
+	}
+	// {{.Name}}SvcImpl is implementation of {{.Name}}Svc
+	{{.Name}}SvcImpl struct {
+		dig.In
+		Repo {{.Package}}_repo.{{.Name}}Repo
+	}
+	// Find{{.Name}}Req find request
+	Find{{.Name}}Req struct {
+		Limit  uint64 ` + "`query:\"limit\"`" + `
+		Offset uint64 ` + "`query:\"offset\"`" + `
+		Sort   string ` + "`query:\"sort\"`" + `
+	}
+	// Find{{.Name}}Resp find {{.Var}} resp
+	Find{{.Name}}Resp struct {
+		{{.Plural}}      []*{{.PkgName}}.{{.Name}}
+		TotalCount string
+	}
+)
+
+// New{{.Name}}Svc return new instance of {{.Name}}Svc
+// @ctor
+func New{{.Name}}Svc(impl {{.Name}}SvcImpl) {{.Name}}Svc {
+	return &impl
+}
+
+// Create {{.Name}}
+func (b *{{.Name}}SvcImpl) Create(ctx context.Context, {{.Var}} *{{.PkgName}}.{{.Name}}) (*{{.PkgName}}.{{.Name}}, error) {
+	if err := validator.New().Struct({{.Var}}); err != nil {
+		return nil, echokit.NewValidErr(err.Error())
+	}
+	id, err := b.Repo.Create(ctx, {{.Var}})
+	if err != nil {
+		return nil, err
+	}
+	return b.findOne(dbtxn.ForcePrimary(ctx), id)
+}
+
+// Find {{.Resource}}
+func (b *{{.Name}}SvcImpl) Find(ctx context.Context, req *Find{{.Name}}Req) (*Find{{.Name}}Resp, error) {
+	var opts []sqkit.SelectOption
+	opts = append(opts, &sqkit.OffsetPagination{Offset: req.Offset, Limit: req.Limit})
+	if req.Sort != "" {
+		opts = append(opts, sqkit.Sorts(strings.Split(req.Sort, ",")))
+	}
+	totalCount, err := b.Repo.Count(ctx)
+	if err != nil {
+		return nil, err
+	}
+	{{.VarPlural}}, err := b.Repo.Find(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Find{{.Name}}Resp{
+		{{.Plural}}:      {{.VarPlural}},
+		TotalCount: fmt.Sprintf("%d", totalCount),
+	}, nil
+}
+
+// FindOne {{.Var}}
+func (b *{{.Name}}SvcImpl) FindOne(ctx context.Context, paramID string) (*{{.PkgName}}.{{.Name}}, error) {
+	{{.ParseKey}}
+	return b.findOne(ctx, id)
+}
+
+func (b *{{.Name}}SvcImpl) findOne(ctx context.Context, id {{.KeyType}}) (*{{.PkgName}}.{{.Name}}, error) {
+	{{.VarPlural}}, err := b.Repo.Find(ctx, sqkit.Eq{ {{.Package}}_repo.{{.Name}}Table.{{.PrimaryKey.Name}}: id})
+	if err != nil {
+		return nil, err
+	} else if len({{.VarPlural}}) < 1 {
+		return nil, echo.ErrNotFound
+	}
+	return {{.VarPlural}}[0], nil
+}
+
+{{if .Version}}// Delete {{.Var}}. Non-zero version only delete the {{.Var}} with same version, otherwise return sqkit.ErrVersionConflict
+func (b *{{.Name}}SvcImpl) Delete(ctx context.Context, paramID string, version {{.Version.Type}}) error {
+	{{.ParseKey}}
+	cond := sqkit.Eq{ {{.Package}}_repo.{{.Name}}Table.{{.PrimaryKey.Name}}: id}
+	if version != 0 {
+		cond[{{.Package}}_repo.{{.Name}}Table.{{.Version.Name}}] = version
+	}
+	affectedRow, err := b.Repo.Delete(ctx, cond)
+	if err == nil && version != 0 && affectedRow < 1 {
+		err = sqkit.ErrVersionConflict
+	}
+	return err
+}
+{{- else}}// Delete {{.Var}}
+func (b *{{.Name}}SvcImpl) Delete(ctx context.Context, paramID string) error {
+	{{.ParseKey}}
+	_, err := b.Repo.Delete(ctx, sqkit.Eq{ {{.Package}}_repo.{{.Name}}Table.{{.PrimaryKey.Name}}: id})
+	return err
+}
+{{- end}}
+
+// Update {{.Var}}
+func (b *{{.Name}}SvcImpl) Update(ctx context.Context, paramID string, {{.Var}} *{{.PkgName}}.{{.Name}}) (*{{.PkgName}}.{{.Name}}, error) {
+	ctx = dbtxn.ForcePrimary(ctx)
+	{{.ParseKey}}
+	if err := validator.New().Struct({{.Var}}); err != nil {
+		return nil, echokit.NewValidErr(err.Error())
+	}
+	if _, err := b.findOne(ctx, id); err != nil {
+		return nil, err
+	}
+	if err := b.update(ctx, id, {{.Var}}); err != nil {
+		return nil, err
+	}
+	return b.findOne(ctx, id)
+}
+
+func (b *{{.Name}}SvcImpl) update(ctx context.Context, id {{.KeyType}}, {{.Var}} *{{.PkgName}}.{{.Name}}) error {
+	affectedRow, err := b.Repo.Update(ctx, {{.Var}}, sqkit.Eq{ {{.Package}}_repo.{{.Name}}Table.{{.PrimaryKey.Name}}: id})
+	if err != nil {
+		return err
+	}
+	if affectedRow < 1 {
+		return echokit.Wrap(echokit.ErrNotFound, errors.New("no affected row"))
+	}
+	return nil
+}
+
+// Patch {{.Var}}. Only non-zero field is patched unless columns is given
+func (b *{{.Name}}SvcImpl) Patch(ctx context.Context, paramID string, {{.Var}} *{{.PkgName}}.{{.Name}}, columns ...string) (*{{.PkgName}}.{{.Name}}, error) {
+	ctx = dbtxn.ForcePrimary(ctx)
+	{{.ParseKey}}
+	if _, err := b.findOne(ctx, id); err != nil {
+		return nil, err
+	}
+	if err := b.patch(ctx, id, {{.Var}}, columns...); err != nil {
+		return nil, err
+	}
+	return b.findOne(ctx, id)
+}
+
+func (b *{{.Name}}SvcImpl) patch(ctx context.Context, id {{.KeyType}}, {{.Var}} *{{.PkgName}}.{{.Name}}, columns ...string) error {
+	affectedRow, err := b.Repo.Patch(ctx, {{.Var}}, sqkit.Eq{ {{.Package}}_repo.{{.Name}}Table.{{.PrimaryKey.Name}}: id}, columns...)
+	if err != nil {
+		return err
+	}
+	if affectedRow < 1 {
+		return echokit.Wrap(echokit.ErrNotFound, errors.New("no affected row"))
+	}
+	return nil
+}
+`
+
+const scaffoldSvcTestTmpl = `package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"{{.PkgPath}}"
+	"{{.RepoPkg}}"
+	"{{.RepoPkg}}_mock"
+
+	"{{.DomainPkg}}/service"
+	"github.com/typical-go/typical-rest-server/pkg/sqkit"
+)
+
+type {{.Var}}SvcFn func(mockRepo *{{.Package}}_repo_mock.Mock{{.Name}}Repo)
+
+func create{{.Name}}Svc(t *testing.T, fn {{.Var}}SvcFn) (service.{{.Name}}Svc, *gomock.Controller) {
+	mock := gomock.NewController(t)
+	mockRepo := {{.Package}}_repo_mock.NewMock{{.Name}}Repo(mock)
+	if fn != nil {
+		fn(mockRepo)
+	}
+
+	return service.New{{.Name}}Svc(service.{{.Name}}SvcImpl{
+		Repo: mockRepo,
+	}), mock
+}
+
+func Test{{.Name}}Svc_Create(t *testing.T) {
+	testcases := []struct {
+		testName    string
+		{{.Var}}SvcFn {{.Var}}SvcFn
+		{{.Var}}        *{{.PkgName}}.{{.Name}}
+		expected    *{{.PkgName}}.{{.Name}}
+		expectedErr string
+	}{
+{{- if .ValidErrs}}
+		{
+			testName:    "validation error",
+			{{.Var}}:        &{{.PkgName}}.{{.Name}}{},
+			expectedErr: {{.ValidErr}},
+		},
+{{- end}}
+		{
+			testName:    "create error",
+			{{.Var}}:        &{{.PkgName}}.{{.Name}}{ {{.Sample}} },
+			expectedErr: "create-error",
+			{{.Var}}SvcFn: func(mockRepo *{{.Package}}_repo_mock.Mock{{.Name}}Repo) {
+				mockRepo.EXPECT().
+					Create(gomock.Any(), &{{.PkgName}}.{{.Name}}{ {{.Sample}} }).
+					Return({{.KeyType}}({{.InvalidKey}}), errors.New("create-error"))
+			},
+		},
+		{
+			testName:    "find error",
+			{{.Var}}:        &{{.PkgName}}.{{.Name}}{ {{.Sample}} },
+			expectedErr: "find-error",
+			{{.Var}}SvcFn: func(mockRepo *{{.Package}}_repo_mock.Mock{{.Name}}Repo) {
+				mockRepo.EXPECT().
+					Create(gomock.Any(), &{{.PkgName}}.{{.Name}}{ {{.Sample}} }).
+					Return({{.SampleKey}}, nil)
+				mockRepo.EXPECT().
+					Find(gomock.Any(), sqkit.Eq{ {{.Package}}_repo.{{.Name}}Table.{{.PrimaryKey.Name}}: {{.SampleKey}}}).
+					Return(nil, errors.New("find-error"))
+			},
+		},
+		{
+			{{.Var}}:     &{{.PkgName}}.{{.Name}}{ {{.Sample}} },
+			expected: &{{.PkgName}}.{{.Name}}{ {{.PrimaryKey.Name}}: {{.SampleKey}}, {{.Sample}} },
+			{{.Var}}SvcFn: func(mockRepo *{{.Package}}_repo_mock.Mock{{.Name}}Repo) {
+				mockRepo.EXPECT().
+					Create(gomock.Any(), &{{.PkgName}}.{{.Name}}{ {{.Sample}} }).
+					Return({{.SampleKey}}, nil)
+				mockRepo.EXPECT().
+					Find(gomock.Any(), sqkit.Eq{ {{.Package}}_repo.{{.Name}}Table.{{.PrimaryKey.Name}}: {{.SampleKey}}}).
+					Return([]*{{.PkgName}}.{{.Name}}{ { {{.PrimaryKey.Name}}: {{.SampleKey}}, {{.Sample}} } }, nil)
+			},
+		},
+	}
+
+	for _, tt := range testcases {
+		t.Run(tt.testName, func(t *testing.T) {
+			svc, mock := create{{.Name}}Svc(t, tt.{{.Var}}SvcFn)
+			defer mock.Finish()
+
+			{{.Var}}, err := svc.Create(context.Background(), tt.{{.Var}})
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expected, {{.Var}})
+			}
+		})
+	}
+}
+
+func Test{{.Name}}Svc_FindOne(t *testing.T) {
+	testcases := []struct {
+		testName    string
+		{{.Var}}SvcFn {{.Var}}SvcFn
+		paramID     string
+		expected    *{{.PkgName}}.{{.Name}}
+		expectedErr string
+	}{
+		{
+			paramID: "1",
+			{{.Var}}SvcFn: func(mockRepo *{{.Package}}_repo_mock.Mock{{.Name}}Repo) {
+				mockRepo.EXPECT().
+					Find(gomock.Any(), sqkit.Eq{ {{.Package}}_repo.{{.Name}}Table.{{.PrimaryKey.Name}}: {{.SampleKey}}}).
+					Return(nil, errors.New("some-error"))
+			},
+			expectedErr: "some-error",
+		},
+		{
+			paramID: "1",
+			{{.Var}}SvcFn: func(mockRepo *{{.Package}}_repo_mock.Mock{{.Name}}Repo) {
+				mockRepo.EXPECT().
+					Find(gomock.Any(), sqkit.Eq{ {{.Package}}_repo.{{.Name}}Table.{{.PrimaryKey.Name}}: {{.SampleKey}}}).
+					Return([]*{{.PkgName}}.{{.Name}}{ { {{.PrimaryKey.Name}}: {{.SampleKey}}, {{.Sample}} } }, nil)
+			},
+			expected: &{{.PkgName}}.{{.Name}}{ {{.PrimaryKey.Name}}: {{.SampleKey}}, {{.Sample}} },
+		},
+		{
+			paramID: "1",
+			{{.Var}}SvcFn: func(mockRepo *{{.Package}}_repo_mock.Mock{{.Name}}Repo) {
+				mockRepo.EXPECT().
+					Find(gomock.Any(), sqkit.Eq{ {{.Package}}_repo.{{.Name}}Table.{{.PrimaryKey.Name}}: {{.SampleKey}}}).
+					Return([]*{{.PkgName}}.{{.Name}}{}, nil)
+			},
+			expectedErr: "code=404, message=Not Found",
+		},
+	}
+	for _, tt := range testcases {
+		t.Run(tt.testName, func(t *testing.T) {
+			svc, mock := create{{.Name}}Svc(t, tt.{{.Var}}SvcFn)
+			defer mock.Finish()
+
+			{{.Var}}, err := svc.FindOne(context.Background(), tt.paramID)
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expected, {{.Var}})
+			}
+		})
+	}
+}
+
+func Test{{.Name}}Svc_Find(t *testing.T) {
+	testcases := []struct {
+		testName    string
+		{{.Var}}SvcFn {{.Var}}SvcFn
+		req         *service.Find{{.Name}}Req
+		expected    *service.Find{{.Name}}Resp
+		expectedErr string
+	}{
+		{
+			{{.Var}}SvcFn: func(mockRepo *{{.Package}}_repo_mock.Mock{{.Name}}Repo) {
+				mockRepo.EXPECT().Count(gomock.Any()).Return(int64(10), nil)
+				mockRepo.EXPECT().
+					Find(gomock.Any(), &sqkit.OffsetPagination{}).
+					Return([]*{{.PkgName}}.{{.Name}}{ { {{.Sample}} } }, nil)
+			},
+			req: &service.Find{{.Name}}Req{},
+			expected: &service.Find{{.Name}}Resp{
+				{{.Plural}}:      []*{{.PkgName}}.{{.Name}}{ { {{.Sample}} } },
+				TotalCount: "10",
+			},
+		},
+		{
+			testName: "count error",
+			{{.Var}}SvcFn: func(mockRepo *{{.Package}}_repo_mock.Mock{{.Name}}Repo) {
+				mockRepo.EXPECT().
+					Count(gomock.Any()).
+					Return(int64(-1), errors.New("count-error"))
+			},
+			req:         &service.Find{{.Name}}Req{Limit: 20, Offset: 10},
+			expectedErr: "count-error",
+		},
+		{
+			testName: "find error",
+			{{.Var}}SvcFn: func(mockRepo *{{.Package}}_repo_mock.Mock{{.Name}}Repo) {
+				mockRepo.EXPECT().Count(gomock.Any()).Return(int64(10), nil)
+				mockRepo.EXPECT().
+					Find(gomock.Any(), &sqkit.OffsetPagination{Limit: 20, Offset: 10}, sqkit.Sorts{"{{.PrimaryKey.Column}}"}).
+					Return(nil, errors.New("find-error"))
+			},
+			req:         &service.Find{{.Name}}Req{Limit: 20, Offset: 10, Sort: "{{.PrimaryKey.Column}}"},
+			expectedErr: "find-error",
+		},
+	}
+	for _, tt := range testcases {
+		t.Run(tt.testName, func(t *testing.T) {
+			svc, mock := create{{.Name}}Svc(t, tt.{{.Var}}SvcFn)
+			defer mock.Finish()
+
+			resp, err := svc.Find(context.Background(), tt.req)
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expected, resp)
+			}
+		})
+	}
+}
+
+func Test{{.Name}}Svc_Delete(t *testing.T) {
+	testcases := []struct {
+		testName    string
+		{{.Var}}SvcFn {{.Var}}SvcFn
+		paramID     string
+		expectedErr string
+	}{
+		{
+			paramID:     "1",
+			expectedErr: "some-error",
+			{{.Var}}SvcFn: func(mockRepo *{{.Package}}_repo_mock.Mock{{.Name}}Repo) {
+				mockRepo.EXPECT().
+					Delete(gomock.Any(), sqkit.Eq{ {{.Package}}_repo.{{.Name}}Table.{{.PrimaryKey.Name}}: {{.SampleKey}}}).
+					Return(int64(0), errors.New("some-error"))
+			},
+		},
+		{
+			paramID: "1",
+			{{.Var}}SvcFn: func(mockRepo *{{.Package}}_repo_mock.Mock{{.Name}}Repo) {
+				mockRepo.EXPECT().
+					Delete(gomock.Any(), sqkit.Eq{ {{.Package}}_repo.{{.Name}}Table.{{.PrimaryKey.Name}}: {{.SampleKey}}}).
+					Return(int64(1), nil)
+			},
+		},
+		{
+			testName: "success even if no affected row (idempotent)",
+			paramID:  "1",
+			{{.Var}}SvcFn: func(mockRepo *{{.Package}}_repo_mock.Mock{{.Name}}Repo) {
+				mockRepo.EXPECT().
+					Delete(gomock.Any(), sqkit.Eq{ {{.Package}}_repo.{{.Name}}Table.{{.PrimaryKey.Name}}: {{.SampleKey}}}).
+					Return(int64(0), nil)
+			},
+		},
+	}
+	for _, tt := range testcases {
+		t.Run(tt.testName, func(t *testing.T) {
+			svc, mock := create{{.Name}}Svc(t, tt.{{.Var}}SvcFn)
+			defer mock.Finish()
+
+			err := svc.Delete(context.Background(), tt.paramID{{if .Version}}, 0{{end}})
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test{{.Name}}Svc_Update(t *testing.T) {
+	testcases := []struct {
+		testName    string
+		{{.Var}}SvcFn {{.Var}}SvcFn
+		paramID     string
+		{{.Var}}        *{{.PkgName}}.{{.Name}}
+		expected    *{{.PkgName}}.{{.Name}}
+		expectedErr string
+	}{
+{{- if .ValidErrs}}
+		{
+			testName:    "bad request",
+			paramID:     "1",
+			{{.Var}}:        &{{.PkgName}}.{{.Name}}{},
+			expectedErr: {{.ValidErr}},
+		},
+{{- end}}
+		{
+			testName:    "update error",
+			paramID:     "1",
+			{{.Var}}:        &{{.PkgName}}.{{.Name}}{ {{.Sample}} },
+			expectedErr: "update error",
+			{{.Var}}SvcFn: func(mockRepo *{{.Package}}_repo_mock.Mock{{.Name}}Repo) {
+				mockRepo.EXPECT().
+					Find(gomock.Any(), sqkit.Eq{ {{.Package}}_repo.{{.Name}}Table.{{.PrimaryKey.Name}}: {{.SampleKey}}}).
+					Return([]*{{.PkgName}}.{{.Name}}{ { {{.PrimaryKey.Name}}: {{.SampleKey}} } }, nil)
+				mockRepo.EXPECT().
+					Update(gomock.Any(), &{{.PkgName}}.{{.Name}}{ {{.Sample}} }, sqkit.Eq{ {{.Package}}_repo.{{.Name}}Table.{{.PrimaryKey.Name}}: {{.SampleKey}}}).
+					Return(int64(-1), errors.New("update error"))
+			},
+		},
+		{
+			testName:    "nothing to update",
+			paramID:     "1",
+			{{.Var}}:        &{{.PkgName}}.{{.Name}}{ {{.Sample}} },
+			expectedErr: "no affected row",
+			{{.Var}}SvcFn: func(mockRepo *{{.Package}}_repo_mock.Mock{{.Name}}Repo) {
+				mockRepo.EXPECT().
+					Find(gomock.Any(), sqkit.Eq{ {{.Package}}_repo.{{.Name}}Table.{{.PrimaryKey.Name}}: {{.SampleKey}}}).
+					Return([]*{{.PkgName}}.{{.Name}}{ { {{.PrimaryKey.Name}}: {{.SampleKey}} } }, nil)
+				mockRepo.EXPECT().
+					Update(gomock.Any(), &{{.PkgName}}.{{.Name}}{ {{.Sample}} }, sqkit.Eq{ {{.Package}}_repo.{{.Name}}Table.{{.PrimaryKey.Name}}: {{.SampleKey}}}).
+					Return(int64(0), nil)
+			},
+		},
+		{
+			testName:    "find error before update",
+			paramID:     "1",
+			{{.Var}}:        &{{.PkgName}}.{{.Name}}{ {{.Sample}} },
+			expectedErr: "find-error",
+			{{.Var}}SvcFn: func(mockRepo *{{.Package}}_repo_mock.Mock{{.Name}}Repo) {
+				mockRepo.EXPECT().
+					Find(gomock.Any(), sqkit.Eq{ {{.Package}}_repo.{{.Name}}Table.{{.PrimaryKey.Name}}: {{.SampleKey}}}).
+					Return(nil, errors.New("find-error"))
+			},
+		},
+		{
+			paramID:  "1",
+			{{.Var}}:     &{{.PkgName}}.{{.Name}}{ {{.Sample}} },
+			expected: &{{.PkgName}}.{{.Name}}{ {{.PrimaryKey.Name}}: {{.SampleKey}}, {{.Sample}} },
+			{{.Var}}SvcFn: func(mockRepo *{{.Package}}_repo_mock.Mock{{.Name}}Repo) {
+				mockRepo.EXPECT().
+					Find(gomock.Any(), sqkit.Eq{ {{.Package}}_repo.{{.Name}}Table.{{.PrimaryKey.Name}}: {{.SampleKey}}}).
+					Return([]*{{.PkgName}}.{{.Name}}{ { {{.PrimaryKey.Name}}: {{.SampleKey}} } }, nil)
+				mockRepo.EXPECT().
+					Update(gomock.Any(), &{{.PkgName}}.{{.Name}}{ {{.Sample}} }, sqkit.Eq{ {{.Package}}_repo.{{.Name}}Table.{{.PrimaryKey.Name}}: {{.SampleKey}}}).
+					Return(int64(1), nil)
+				mockRepo.EXPECT().
+					Find(gomock.Any(), sqkit.Eq{ {{.Package}}_repo.{{.Name}}Table.{{.PrimaryKey.Name}}: {{.SampleKey}}}).
+					Return([]*{{.PkgName}}.{{.Name}}{ { {{.PrimaryKey.Name}}: {{.SampleKey}}, {{.Sample}} } }, nil)
+			},
+		},
+	}
+	for _, tt := range testcases {
+		t.Run(tt.testName, func(t *testing.T) {
+			svc, mock := create{{.Name}}Svc(t, tt.{{.Var}}SvcFn)
+			defer mock.Finish()
+
+			{{.Var}}, err := svc.Update(context.Background(), tt.paramID, tt.{{.Var}})
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expected, {{.Var}})
+			}
+		})
+	}
+}
+
+func Test{{.Name}}Svc_Patch(t *testing.T) {
+	testcases := []struct {
+		testName    string
+		{{.Var}}SvcFn {{.Var}}SvcFn
+		paramID     string
+		{{.Var}}        *{{.PkgName}}.{{.Name}}
+		columns     []string
+		expected    *{{.PkgName}}.{{.Name}}
+		expectedErr string
+	}{
+		{
+			testName:    "patch error",
+			paramID:     "1",
+			{{.Var}}:        &{{.PkgName}}.{{.Name}}{ {{.Sample}} },
+			expectedErr: "patch-error",
+			{{.Var}}SvcFn: func(mockRepo *{{.Package}}_repo_mock.Mock{{.Name}}Repo) {
+				mockRepo.EXPECT().
+					Find(gomock.Any(), sqkit.Eq{ {{.Package}}_repo.{{.Name}}Table.{{.PrimaryKey.Name}}: {{.SampleKey}}}).
+					Return([]*{{.PkgName}}.{{.Name}}{ { {{.PrimaryKey.Name}}: {{.SampleKey}} } }, nil)
+				mockRepo.EXPECT().
+					Patch(gomock.Any(), &{{.PkgName}}.{{.Name}}{ {{.Sample}} }, sqkit.Eq{ {{.Package}}_repo.{{.Name}}Table.{{.PrimaryKey.Name}}: {{.SampleKey}}}).
+					Return(int64(-1), errors.New("patch-error"))
+			},
+		},
+		{
+			testName:    "find error before patch",
+			paramID:     "1",
+			{{.Var}}:        &{{.PkgName}}.{{.Name}}{ {{.Sample}} },
+			expectedErr: "find-error",
+			{{.Var}}SvcFn: func(mockRepo *{{.Package}}_repo_mock.Mock{{.Name}}Repo) {
+				mockRepo.EXPECT().
+					Find(gomock.Any(), sqkit.Eq{ {{.Package}}_repo.{{.Name}}Table.{{.PrimaryKey.Name}}: {{.SampleKey}}}).
+					Return(nil, errors.New("find-error"))
+			},
+		},
+		{
+			testName: "patch with columns",
+			paramID:  "1",
+			{{.Var}}:     &{{.PkgName}}.{{.Name}}{ {{.Sample}} },
+			columns:  []string{ {{.SampleColumns}} },
+			expected: &{{.PkgName}}.{{.Name}}{ {{.PrimaryKey.Name}}: {{.SampleKey}}, {{.Sample}} },
+			{{.Var}}SvcFn: func(mockRepo *{{.Package}}_repo_mock.Mock{{.Name}}Repo) {
+				mockRepo.EXPECT().
+					Find(gomock.Any(), sqkit.Eq{ {{.Package}}_repo.{{.Name}}Table.{{.PrimaryKey.Name}}: {{.SampleKey}}}).
+					Return([]*{{.PkgName}}.{{.Name}}{ { {{.PrimaryKey.Name}}: {{.SampleKey}} } }, nil)
+				mockRepo.EXPECT().
+					Patch(gomock.Any(), &{{.PkgName}}.{{.Name}}{ {{.Sample}} }, sqkit.Eq{ {{.Package}}_repo.{{.Name}}Table.{{.PrimaryKey.Name}}: {{.SampleKey}}}{{range .SampleCols}}, "{{.}}"{{end}}).
+					Return(int64(1), nil)
+				mockRepo.EXPECT().
+					Find(gomock.Any(), sqkit.Eq{ {{.Package}}_repo.{{.Name}}Table.{{.PrimaryKey.Name}}: {{.SampleKey}}}).
+					Return([]*{{.PkgName}}.{{.Name}}{ { {{.PrimaryKey.Name}}: {{.SampleKey}}, {{.Sample}} } }, nil)
+			},
+		},
+	}
+	for _, tt := range testcases {
+		t.Run(tt.testName, func(t *testing.T) {
+			svc, mock := create{{.Name}}Svc(t, tt.{{.Var}}SvcFn)
+			defer mock.Finish()
+
+			{{.Var}}, err := svc.Patch(context.Background(), tt.paramID, tt.{{.Var}}, tt.columns...)
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expected, {{.Var}})
+			}
+		})
+	}
+}
+`
+
+const scaffoldCntrlTmpl = `package controller
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"{{.PkgPath}}"
+	"{{.DomainPkg}}/service"
+	"github.com/typical-go/typical-rest-server/pkg/cachekit"{{if .Version}}
+	"github.com/typical-go/typical-rest-server/pkg/dbtxn"{{end}}
+	"github.com/typical-go/typical-rest-server/pkg/echokit"
+	"github.com/typical-go/typical-rest-server/pkg/sqkit"
+	"go.uber.org/dig"
+)
+
+type (
+	// {{.Name}}Cntrl is controller to {{.Var}} entity
+	{{.Name}}Cntrl struct {
+		dig.In
+		Svc   service.{{.Name}}Svc
+		Cache *cachekit.Store
+	}
+)
+
+var _ echokit.Router = (*{{.Name}}Cntrl)(nil)
+
+// SetRoute to define API Route
+func (c *{{.Name}}Cntrl) SetRoute(e echokit.Server) {
+	e.GET("/{{.Resource}}", c.Find, c.Cache.Middleware)
+	e.GET("/{{.Resource}}/:id", c.FindOne, c.Cache.Middleware)
+	e.HEAD("/{{.Resource}}/:id", c.FindOne, c.Cache.Middleware)
+	e.POST("/{{.Resource}}", c.Create)
+	e.PUT("/{{.Resource}}/:id", c.Update)
+	e.PATCH("/{{.Resource}}/:id", c.Patch)
+	e.DELETE("/{{.Resource}}/:id", c.Delete)
+}
+
+// Create {{.Var}}
+func (c *{{.Name}}Cntrl) Create(ec echo.Context) (err error) {
+	var {{.Var}} {{.PkgName}}.{{.Name}}
+	if err = ec.Bind(&{{.Var}}); err != nil {
+		return err
+	}
+	ctx := ec.Request().Context()
+	new{{.Name}}, err := c.Svc.Create(ctx, &{{.Var}})
+	if err != nil {
+		return echokit.HTTPError(err)
+	}
+	ec.Response().Header().Set(echo.HeaderLocation, fmt.Sprintf("/{{.Resource}}/{{.LocationVerb}}", new{{.Name}}.{{.PrimaryKey.Name}}))
+{{- if .Version}}
+	ec.Response().Header().Set(echokit.HeaderETag, {{.Var}}ETag(new{{.Name}}))
+{{- end}}
+	return ec.JSON(http.StatusCreated, new{{.Name}})
+}
+
+// Find {{.Resource}}
+func (c *{{.Name}}Cntrl) Find(ec echo.Context) (err error) {
+	var req service.Find{{.Name}}Req
+	if err = ec.Bind(&req); err != nil {
+		return err
+	}
+	ctx := ec.Request().Context()
+	resp, err := c.Svc.Find(ctx, &req)
+	if err != nil {
+		return echokit.HTTPError(err)
+	}
+	ec.Response().Header().Add(echokit.HeaderTotalCount, resp.TotalCount)
+	return ec.JSON(http.StatusOK, resp.{{.Plural}})
+}
+
+// FindOne {{.Var}}
+func (c *{{.Name}}Cntrl) FindOne(ec echo.Context) error {
+	{{.Var}}, err := c.Svc.FindOne(
+		ec.Request().Context(),
+		ec.Param("id"),
+	)
+	if err != nil {
+		return echokit.HTTPError(err)
+	}
+{{- if .Version}}
+	ec.Response().Header().Set(echokit.HeaderETag, {{.Var}}ETag({{.Var}}))
+{{- end}}
+	return ec.JSON(http.StatusOK, {{.Var}})
+}
+
+// Delete {{.Var}}
+func (c *{{.Name}}Cntrl) Delete(ec echo.Context) (err error) {
+	ctx := ec.Request().Context()
+	id := ec.Param("id")
+{{- if .Version}}
+	current, err := c.ifMatch(ec)
+	if err != nil {
+		return err
+	}
+	var version {{.Version.Type}}
+	if current != nil {
+		version = current.{{.Version.Name}}
+	}
+	if err = c.Svc.Delete(ctx, id, version); err != nil {
+		return preconditionError(current, err)
+	}
+{{- else}}
+	if err = c.Svc.Delete(ctx, id); err != nil {
+		return echokit.HTTPError(err)
+	}
+{{- end}}
+	return ec.NoContent(http.StatusNoContent)
+}
+
+// Update {{.Var}}
+func (c *{{.Name}}Cntrl) Update(ec echo.Context) (err error) {
+	var {{.Var}} {{.PkgName}}.{{.Name}}
+	if err = ec.Bind(&{{.Var}}); err != nil {
+		return err
+	}
+	ctx := ec.Request().Context()
+	paramID := ec.Param("id")
+{{- if .Version}}
+	current, err := c.ifMatch(ec)
+	if err != nil {
+		return err
+	}
+	if current != nil {
+		{{.Var}}.{{.Version.Name}} = current.{{.Version.Name}}
+	}
+	updated{{.Name}}, err := c.Svc.Update(ctx, paramID, &{{.Var}})
+	if err != nil {
+		return preconditionError(current, err)
+	}
+	ec.Response().Header().Set(echokit.HeaderETag, {{.Var}}ETag(updated{{.Name}}))
+{{- else}}
+	updated{{.Name}}, err := c.Svc.Update(ctx, paramID, &{{.Var}})
+	if err != nil {
+		return echokit.HTTPError(err)
+	}
+{{- end}}
+	return ec.JSON(http.StatusOK, updated{{.Name}})
+}
+
+// Patch {{.Var}}. Field in JSON body is patched even with zero or null value
+func (c *{{.Name}}Cntrl) Patch(ec echo.Context) (err error) {
+	var {{.Var}} {{.PkgName}}.{{.Name}}
+	keys, err := echokit.BindPatch(ec, &{{.Var}})
+	if err != nil {
+		return err
+	}
+	ctx := ec.Request().Context()
+	paramID := ec.Param("id")
+{{- if .Version}}
+	current, err := c.ifMatch(ec)
+	if err != nil {
+		return err
+	}
+	if current != nil {
+		{{.Var}}.{{.Version.Name}} = current.{{.Version.Name}}
+	}
+	patched{{.Name}}, err := c.Svc.Patch(ctx, paramID, &{{.Var}}, sqkit.JSONColumns(&{{.Var}}, keys...)...)
+	if err != nil {
+		return preconditionError(current, err)
+	}
+	ec.Response().Header().Set(echokit.HeaderETag, {{.Var}}ETag(patched{{.Name}}))
+{{- else}}
+	patched{{.Name}}, err := c.Svc.Patch(ctx, paramID, &{{.Var}}, sqkit.JSONColumns(&{{.Var}}, keys...)...)
+	if err != nil {
+		return echokit.HTTPError(err)
+	}
+{{- end}}
+	return ec.JSON(http.StatusOK, patched{{.Name}})
+}
+{{- if .Version}}
+
+// ifMatch compare ` + "`If-Match`" + ` header with ETag of current {{.Var}}. Return the current {{.Var}} if header available
+func (c *{{.Name}}Cntrl) ifMatch(ec echo.Context) (*{{.PkgName}}.{{.Name}}, error) {
+	ifMatch := ec.Request().Header.Get(echokit.HeaderIfMatch)
+	if ifMatch == "" {
+		return nil, nil
+	}
+	current, err := c.Svc.FindOne(dbtxn.ForcePrimary(ec.Request().Context()), ec.Param("id"))
+	if err != nil {
+		return nil, echokit.HTTPError(err)
+	}
+	if !echokit.MatchETag(ifMatch, {{.Var}}ETag(current)) {
+		return nil, echo.NewHTTPError(http.StatusPreconditionFailed)
+	}
+	return current, nil
+}
+
+// preconditionError return 412 if the {{.Var}} changed after ` + "`If-Match`" + ` checked
+func preconditionError(current *{{.PkgName}}.{{.Name}}, err error) error {
+	if current != nil && errors.Is(err, sqkit.ErrVersionConflict) {
+		return echo.NewHTTPError(http.StatusPreconditionFailed)
+	}
+	return echokit.HTTPError(err)
+}
+
+func {{.Var}}ETag({{.Var}} *{{.PkgName}}.{{.Name}}) string {
+	return echokit.ETag({{.Var}}.{{.PrimaryKey.Name}}, {{.Var}}.{{.Version.Name}})
+}
+{{- end}}
+`
+
+const scaffoldCntrlTestTmpl = `package controller_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+	"{{.PkgPath}}"
+	"{{.DomainPkg}}/controller"
+	"{{.DomainPkg}}/service"
+	"{{.DomainPkg}}/service_mock"
+	"github.com/typical-go/typical-rest-server/pkg/echokit"
+	"github.com/typical-go/typical-rest-server/pkg/echotest"
+)
+
+type (
+	{{.Name}}CntrlFn       func(*service_mock.Mock{{.Name}}Svc)
+	{{.Name}}CntrlTestCase struct {
+		TestName string
+		echotest.TestCase
+		{{.Name}}CntrlFn
+	}
+)
+
+var sample{{.Name}} = &{{.PkgName}}.{{.Name}}{ {{.PrimaryKey.Name}}: {{.SampleKey}}, {{.Sample}} }
+
+func Create{{.Name}}Cntrl(t *testing.T, fn {{.Name}}CntrlFn) (*controller.{{.Name}}Cntrl, *gomock.Controller) {
+	mock := gomock.NewController(t)
+	mockSvc := service_mock.NewMock{{.Name}}Svc(mock)
+	if fn != nil {
+		fn(mockSvc)
+	}
+	return &controller.{{.Name}}Cntrl{Svc: mockSvc}, mock
+}
+
+// {{.Var}}JSON return JSON response body of the value
+func {{.Var}}JSON(v interface{}) string {
+	b, _ := json.Marshal(v)
+	return string(b) + "\n"
+}
+
+func Test{{.Name}}Cntrl_SetRoute(t *testing.T) {
+	e := echo.New()
+	echokit.SetRoute(e, &controller.{{.Name}}Cntrl{})
+	require.Equal(t, []string{
+		"/{{.Resource}}\tGET,POST",
+		"/{{.Resource}}/:id\tDELETE,GET,HEAD,PATCH,PUT",
+	}, echokit.DumpEcho(e))
+}
+
+func Test{{.Name}}Cntrl_FindOne(t *testing.T) {
+	testcases := []{{.Name}}CntrlTestCase{
+		{
+			TestName: "valid ID",
+			TestCase: echotest.TestCase{
+				Request: echotest.Request{
+					Method:    http.MethodGet,
+					Target:    "/",
+					URLParams: map[string]string{"id": "1"},
+				},
+				ExpectedResponse: echotest.Response{
+					Code: http.StatusOK,
+					Body: {{.Var}}JSON(sample{{.Name}}),
+					Header: http.Header{
+						"Content-Type": {"application/json; charset=UTF-8"},
+{{- if .Version}}
+						"Etag":         {echokit.ETag(sample{{.Name}}.{{.PrimaryKey.Name}}, sample{{.Name}}.{{.Version.Name}})},
+{{- end}}
+					},
+				},
+			},
+			{{.Name}}CntrlFn: func(svc *service_mock.Mock{{.Name}}Svc) {
+				svc.EXPECT().FindOne(gomock.Any(), "1").Return(sample{{.Name}}, nil)
+			},
+		},
+		{
+			TestName: "entity not found",
+			TestCase: echotest.TestCase{
+				Request: echotest.Request{
+					Method:    http.MethodGet,
+					Target:    "/",
+					URLParams: map[string]string{"id": "3"},
+				},
+				ExpectedError: "code=404, message=Not Found",
+			},
+			{{.Name}}CntrlFn: func(svc *service_mock.Mock{{.Name}}Svc) {
+				svc.EXPECT().FindOne(gomock.Any(), "3").Return(nil, echo.NewHTTPError(404))
+			},
+		},
+		{
+			TestName: "error",
+			TestCase: echotest.TestCase{
+				Request: echotest.Request{
+					Method:    http.MethodGet,
+					Target:    "/",
+					URLParams: map[string]string{"id": "2"},
+				},
+				ExpectedError: "code=500, message=Internal Server Error, internal=some-error",
+			},
+			{{.Name}}CntrlFn: func(svc *service_mock.Mock{{.Name}}Svc) {
+				svc.EXPECT().FindOne(gomock.Any(), "2").Return(nil, errors.New("some-error"))
+			},
+		},
+	}
+
+	for _, tt := range testcases {
+		t.Run(tt.TestName, func(t *testing.T) {
+			cntrl, mock := Create{{.Name}}Cntrl(t, tt.{{.Name}}CntrlFn)
+			defer mock.Finish()
+			tt.Execute(t, cntrl.FindOne)
+		})
+	}
+}
+
+func Test{{.Name}}Cntrl_Find(t *testing.T) {
+	testcases := []{{.Name}}CntrlTestCase{
+		{
+			TestCase: echotest.TestCase{
+				Request: echotest.Request{
+					Method: http.MethodGet,
+					Target: "/",
+				},
+				ExpectedResponse: echotest.Response{
+					Code: http.StatusOK,
+					Body: {{.Var}}JSON([]*{{.PkgName}}.{{.Name}}{sample{{.Name}} }),
+					Header: http.Header{
+						"Content-Type":  {"application/json; charset=UTF-8"},
+						"X-Total-Count": {"10"},
+					},
+				},
+			},
+			{{.Name}}CntrlFn: func(svc *service_mock.Mock{{.Name}}Svc) {
+				svc.EXPECT().
+					Find(gomock.Any(), &service.Find{{.Name}}Req{}).
+					Return(&service.Find{{.Name}}Resp{
+						TotalCount: "10",
+						{{.Plural}}:      []*{{.PkgName}}.{{.Name}}{sample{{.Name}} },
+					}, nil)
+			},
+		},
+		{
+			TestCase: echotest.TestCase{
+				Request: echotest.Request{
+					Method: http.MethodGet,
+					Target: "/?limit=20&offset=10&sort={{.PrimaryKey.Column}}",
+				},
+				ExpectedError: "code=500, message=Internal Server Error, internal=some-error",
+			},
+			{{.Name}}CntrlFn: func(svc *service_mock.Mock{{.Name}}Svc) {
+				svc.EXPECT().
+					Find(gomock.Any(), &service.Find{{.Name}}Req{Limit: 20, Offset: 10, Sort: "{{.PrimaryKey.Column}}"}).
+					Return(nil, errors.New("some-error"))
+			},
+		},
+	}
+
+	for _, tt := range testcases {
+		t.Run(tt.TestName, func(t *testing.T) {
+			cntrl, mock := Create{{.Name}}Cntrl(t, tt.{{.Name}}CntrlFn)
+			defer mock.Finish()
+			tt.Execute(t, cntrl.Find)
+		})
+	}
+}
+
+func Test{{.Name}}Cntrl_Create(t *testing.T) {
+	testcases := []{{.Name}}CntrlTestCase{
+		{
+			TestCase: echotest.TestCase{
+				Request: echotest.Request{
+					Method: http.MethodPost,
+					Target: "/",
+					Body:   ` + "`invalid}`" + `,
+					Header: http.Header{"Content-Type": {"application/json"}},
+				},
+				ExpectedError: "code=400, message=Syntax error: offset=1, error=invalid character 'i' looking for beginning of value, internal=invalid character 'i' looking for beginning of value",
+			},
+		},
+		{
+			TestCase: echotest.TestCase{
+				Request: echotest.Request{
+					Method: http.MethodPost,
+					Target: "/",
+					Body:   ` + "`{{.SampleJSON}}`" + `,
+					Header: http.Header{"Content-Type": {"application/json"}},
+				},
+				ExpectedError: "code=500, message=Internal Server Error, internal=some-error",
+			},
+			{{.Name}}CntrlFn: func(svc *service_mock.Mock{{.Name}}Svc) {
+				svc.EXPECT().Create(gomock.Any(), &{{.PkgName}}.{{.Name}}{ {{.Sample}} }).Return(nil, errors.New("some-error"))
+			},
+		},
+		{
+			TestCase: echotest.TestCase{
+				Request: echotest.Request{
+					Method: http.MethodPost,
+					Target: "/",
+					Body:   ` + "`{{.SampleJSON}}`" + `,
+					Header: http.Header{"Content-Type": {"application/json"}},
+				},
+				ExpectedResponse: echotest.Response{
+					Body: {{.Var}}JSON(sample{{.Name}}),
+					Code: http.StatusCreated,
+					Header: http.Header{
+						"Content-Type": {"application/json; charset=UTF-8"},
+{{- if .Version}}
+						"Etag":         {echokit.ETag(sample{{.Name}}.{{.PrimaryKey.Name}}, sample{{.Name}}.{{.Version.Name}})},
+{{- end}}
+						"Location":     {"/{{.Resource}}/1"},
+					},
+				},
+			},
+			{{.Name}}CntrlFn: func(svc *service_mock.Mock{{.Name}}Svc) {
+				svc.EXPECT().
+					Create(gomock.Any(), &{{.PkgName}}.{{.Name}}{ {{.Sample}} }).
+					Return(sample{{.Name}}, nil)
+			},
+		},
+	}
+
+	for _, tt := range testcases {
+		t.Run(tt.TestName, func(t *testing.T) {
+			cntrl, mock := Create{{.Name}}Cntrl(t, tt.{{.Name}}CntrlFn)
+			defer mock.Finish()
+			tt.Execute(t, cntrl.Create)
+		})
+	}
+}
+
+func Test{{.Name}}Cntrl_Update(t *testing.T) {
+	testcases := []{{.Name}}CntrlTestCase{
+		{
+			TestCase: echotest.TestCase{
+				Request: echotest.Request{
+					Method:    http.MethodPut,
+					Target:    "/",
+					URLParams: map[string]string{"id": "1"},
+					Header:    http.Header{"Content-Type": {"application/json"}},
+					Body:      ` + "`{bad-json`" + `,
+				},
+				ExpectedError: "code=400, message=Syntax error: offset=2, error=invalid character 'b' looking for beginning of object key string, internal=invalid character 'b' looking for beginning of object key string",
+			},
+		},
+		{
+			TestCase: echotest.TestCase{
+				Request: echotest.Request{
+					Method:    http.MethodPut,
+					Target:    "/",
+					URLParams: map[string]string{"id": "1"},
+					Header:    http.Header{"Content-Type": {"application/json"}},
+					Body:      ` + "`{{.SampleJSON}}`" + `,
+				},
+				ExpectedError: "code=500, message=Internal Server Error, internal=some-error",
+			},
+			{{.Name}}CntrlFn: func(svc *service_mock.Mock{{.Name}}Svc) {
+				svc.EXPECT().
+					Update(gomock.Any(), "1", gomock.Any()).
+					Return(nil, errors.New("some-error"))
+			},
+		},
+		{
+			TestCase: echotest.TestCase{
+				Request: echotest.Request{
+					Method:    http.MethodPut,
+					Target:    "/",
+					URLParams: map[string]string{"id": "1"},
+					Header:    http.Header{"Content-Type": {"application/json"}},
+					Body:      ` + "`{{.SampleJSON}}`" + `,
+				},
+				ExpectedResponse: echotest.Response{
+					Code: http.StatusOK,
+					Body: {{.Var}}JSON(sample{{.Name}}),
+					Header: http.Header{
+						"Content-Type": {"application/json; charset=UTF-8"},
+{{- if .Version}}
+						"Etag":         {echokit.ETag(sample{{.Name}}.{{.PrimaryKey.Name}}, sample{{.Name}}.{{.Version.Name}})},
+{{- end}}
+					},
+				},
+			},
+			{{.Name}}CntrlFn: func(svc *service_mock.Mock{{.Name}}Svc) {
+				svc.EXPECT().
+					Update(gomock.Any(), "1", gomock.Any()).
+					Return(sample{{.Name}}, nil)
+			},
+		},
+{{- if .Version}}
+		{
+			TestName: "if-match not match",
+			TestCase: echotest.TestCase{
+				Request: echotest.Request{
+					Method:    http.MethodPut,
+					Target:    "/",
+					URLParams: map[string]string{"id": "1"},
+					Header: http.Header{
+						"Content-Type": {"application/json"},
+						"If-Match":     {"\"stale-etag\""},
+					},
+					Body: ` + "`{{.SampleJSON}}`" + `,
+				},
+				ExpectedError: "code=412, message=Precondition Failed",
+			},
+			{{.Name}}CntrlFn: func(svc *service_mock.Mock{{.Name}}Svc) {
+				svc.EXPECT().FindOne(gomock.Any(), "1").Return(sample{{.Name}}, nil)
+			},
+		},
+{{- end}}
+	}
+
+	for _, tt := range testcases {
+		t.Run(tt.TestName, func(t *testing.T) {
+			cntrl, mock := Create{{.Name}}Cntrl(t, tt.{{.Name}}CntrlFn)
+			defer mock.Finish()
+			tt.Execute(t, cntrl.Update)
+		})
+	}
+}
+
+func Test{{.Name}}Cntrl_Patch(t *testing.T) {
+	testcases := []{{.Name}}CntrlTestCase{
+		{
+			TestCase: echotest.TestCase{
+				Request: echotest.Request{
+					Method:    http.MethodPatch,
+					Target:    "/",
+					URLParams: map[string]string{"id": "1"},
+					Header:    http.Header{"Content-Type": {"application/json"}},
+					Body:      ` + "`{bad-json`" + `,
+				},
+				ExpectedError: "code=400, message=Syntax error: offset=2, error=invalid character 'b' looking for beginning of object key string, internal=invalid character 'b' looking for beginning of object key string",
+			},
+		},
+		{
+			TestCase: echotest.TestCase{
+				Request: echotest.Request{
+					Method:    http.MethodPatch,
+					Target:    "/",
+					URLParams: map[string]string{"id": "1"},
+					Header:    http.Header{"Content-Type": {"application/json"}},
+					Body:      ` + "`{{.SampleJSON}}`" + `,
+				},
+				ExpectedError: "code=500, message=Internal Server Error, internal=some-error",
+			},
+			{{.Name}}CntrlFn: func(svc *service_mock.Mock{{.Name}}Svc) {
+				svc.EXPECT().
+					Patch(gomock.Any(), "1", gomock.Any(){{range .SampleCols}}, "{{.}}"{{end}}).
+					Return(nil, errors.New("some-error"))
+			},
+		},
+		{
+			TestCase: echotest.TestCase{
+				Request: echotest.Request{
+					Method:    http.MethodPatch,
+					Target:    "/",
+					URLParams: map[string]string{"id": "1"},
+					Header:    http.Header{"Content-Type": {"application/json"}},
+					Body:      ` + "`{{.SampleJSON}}`" + `,
+				},
+				ExpectedResponse: echotest.Response{
+					Code: http.StatusOK,
+					Body: {{.Var}}JSON(sample{{.Name}}),
+					Header: http.Header{
+						"Content-Type": {"application/json; charset=UTF-8"},
+{{- if .Version}}
+						"Etag":         {echokit.ETag(sample{{.Name}}.{{.PrimaryKey.Name}}, sample{{.Name}}.{{.Version.Name}})},
+{{- end}}
+					},
+				},
+			},
+			{{.Name}}CntrlFn: func(svc *service_mock.Mock{{.Name}}Svc) {
+				svc.EXPECT().
+					Patch(gomock.Any(), "1", gomock.Any(){{range .SampleCols}}, "{{.}}"{{end}}).
+					Return(sample{{.Name}}, nil)
+			},
+		},
+	}
+
+	for _, tt := range testcases {
+		t.Run(tt.TestName, func(t *testing.T) {
+			cntrl, mock := Create{{.Name}}Cntrl(t, tt.{{.Name}}CntrlFn)
+			defer mock.Finish()
+			tt.Execute(t, cntrl.Patch)
+		})
+	}
+}
+
+func Test{{.Name}}Cntrl_Delete(t *testing.T) {
+	testcases := []{{.Name}}CntrlTestCase{
+		{
+			TestCase: echotest.TestCase{
+				Request: echotest.Request{
+					Method:    http.MethodDelete,
+					Target:    "/",
+					URLParams: map[string]string{"id": "1"},
+				},
+				ExpectedResponse: echotest.Response{
+					Code:   http.StatusNoContent,
+					Header: http.Header{},
+				},
+			},
+			{{.Name}}CntrlFn: func(svc *service_mock.Mock{{.Name}}Svc) {
+				svc.EXPECT().Delete(gomock.Any(), "1"{{if .Version}}, {{.Version.Type}}(0){{end}}).Return(nil)
+			},
+		},
+		{
+			TestCase: echotest.TestCase{
+				Request: echotest.Request{
+					Method:    http.MethodDelete,
+					Target:    "/",
+					URLParams: map[string]string{"id": "1"},
+				},
+				ExpectedError: "code=500, message=Internal Server Error, internal=some-error",
+			},
+			{{.Name}}CntrlFn: func(svc *service_mock.Mock{{.Name}}Svc) {
+				svc.EXPECT().Delete(gomock.Any(), "1"{{if .Version}}, {{.Version.Type}}(0){{end}}).Return(errors.New("some-error"))
+			},
+		},
+{{- if .Version}}
+		{
+			TestName: "if-match not match",
+			TestCase: echotest.TestCase{
+				Request: echotest.Request{
+					Method:    http.MethodDelete,
+					Target:    "/",
+					URLParams: map[string]string{"id": "1"},
+					Header:    http.Header{"If-Match": {"\"stale-etag\""}},
+				},
+				ExpectedError: "code=412, message=Precondition Failed",
+			},
+			{{.Name}}CntrlFn: func(svc *service_mock.Mock{{.Name}}Svc) {
+				svc.EXPECT().FindOne(gomock.Any(), "1").Return(sample{{.Name}}, nil)
+			},
+		},
+{{- end}}
+	}
+
+	for _, tt := range testcases {
+		t.Run(tt.TestName, func(t *testing.T) {
+			cntrl, mock := Create{{.Name}}Cntrl(t, tt.{{.Name}}CntrlFn)
+			defer mock.Finish()
+			tt.Execute(t, cntrl.Delete)
+		})
+	}
+}
+`
+
+const scaffoldRouterTmpl = `package {{.Domain}}
+
+import (
+	"{{.DomainPkg}}/controller"
+	"github.com/typical-go/typical-rest-server/pkg/echokit"
+	"go.uber.org/dig"
+)
+
+type (
+	// Router to server
+	Router struct {
+		dig.In
+		{{.Name}}Cntrl controller.{{.Name}}Cntrl
+	}
+)
+
+var _ echokit.Router = (*Router)(nil)
+
+// SetRoute to echo server
+func (r *Router) SetRoute(e echokit.Server) {
+	group := e.Group("/{{.Domain}}")
+	echokit.SetRoute(group,
+		&r.{{.Name}}Cntrl,
+	)
+}
+`
+
+const scaffoldRouterTestTmpl = `package {{.Domain}}_test
+
+import (
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+	"{{.DomainPkg}}"
+	"github.com/typical-go/typical-rest-server/pkg/echokit"
+)
+
+func TestRoute(t *testing.T) {
+	e := echo.New()
+	echokit.SetRoute(e, &{{.Domain}}.Router{})
+	require.Equal(t, []string{
+		"/{{.Domain}}/{{.Resource}}\tGET,POST",
+		"/{{.Domain}}/{{.Resource}}/:id\tDELETE,GET,HEAD,PATCH,PUT",
+	}, echokit.DumpEcho(e))
+}
+`
@@ -0,0 +1,171 @@
+package typrepo
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+)
+
+type (
+	// Schema of database tables
+	Schema struct {
+		Tables []*Table `json:"tables"`
+	}
+	// Table schema
+	Table struct {
+		Name       string    `json:"name"`
+		Columns    []*Column `json:"columns"`
+		PrimaryKey []string  `json:"primary_key,omitempty"`
+		Unique     []string  `json:"unique,omitempty"`
+	}
+	// Column schema
+	Column struct {
+		Name     string `json:"name"`
+		Type     string `json:"type"`
+		Nullable bool   `json:"nullable,omitempty"`
+		Auto     bool   `json:"auto,omitempty"`
+		Default  string `json:"default,omitempty"`
+	}
+)
+
+// CreateSchema create schema from entities
+func CreateSchema(entities []*Entity) *Schema {
+	schema := &Schema{}
+	for _, entity := range entities {
+		schema.Tables = append(schema.Tables, CreateTable(entity))
+	}
+	return schema
+}
+
+// CreateTable create table schema from entity
+func CreateTable(e *Entity) *Table {
+	table := &Table{Name: e.Table}
+	for _, f := range e.Fields {
+		table.Columns = append(table.Columns, &Column{
+			Name:     f.Column,
+			Type:     columnType(e.Dialect, f),
+			Nullable: f.Nullable,
+			Auto:     f.Auto,
+			Default:  columnDefault(e.Dialect, f),
+		})
+	}
+	for _, f := range e.PrimaryKeys {
+		table.PrimaryKey = append(table.PrimaryKey, f.Column)
+	}
+	for _, f := range e.UniqueKeys {
+		table.Unique = append(table.Unique, f.Column)
+	}
+	return table
+}
+
+// ReadSchema read schema from json file. Return empty schema if file not exist
+func ReadSchema(path string) (*Schema, error) {
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &Schema{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var schema Schema
+	if err := json.Unmarshal(b, &schema); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &schema, nil
+}
+
+// WriteSchema write schema to json file
+func WriteSchema(path string, schema *Schema) error {
+	b, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, append(b, '\n'), 0644)
+}
+
+// Table return table by name
+func (s *Schema) Table(name string) *Table {
+	for _, t := range s.Tables {
+		if t.Name == name {
+			return t
+		}
+	}
+	return nil
+}
+
+// Column return column by name
+func (t *Table) Column(name string) *Column {
+	for _, c := range t.Columns {
+		if c.Name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+func columnType(dialect string, f *Field) string {
+	if f.ColumnType != "" {
+		return f.ColumnType
+	}
+	typ := strings.TrimPrefix(f.Type, "*")
+	dialect = strings.ToLower(dialect)
+	switch typ {
+	case "int", "int32", "uint", "uint32", "sql.NullInt32":
+		if dialect == "postgres" && f.Auto {
+			return "SERIAL"
+		}
+		return "INTEGER"
+	case "int8", "int16", "uint8", "uint16", "sql.NullInt16", "sql.NullByte":
+		return "SMALLINT"
+	case "int64", "uint64", "sql.NullInt64":
+		switch {
+		case dialect == "postgres" && f.Auto:
+			return "BIGSERIAL"
+		case dialect == "sqlite":
+			return "INTEGER" // required for AUTOINCREMENT
+		}
+		return "BIGINT"
+	case "float32":
+		return "REAL"
+	case "float64", "sql.NullFloat64":
+		if dialect == "postgres" {
+			return "DOUBLE PRECISION"
+		}
+		return "DOUBLE"
+	case "bool", "sql.NullBool":
+		return "BOOLEAN"
+	case "time.Time", "sql.NullTime":
+		return "TIMESTAMP"
+	case "[]byte":
+		if dialect == "postgres" {
+			return "BYTEA"
+		}
+		return "BLOB"
+	case "string", "sql.NullString":
+		if dialect == "postgres" && f.Auto {
+			return "UUID"
+		}
+		return "VARCHAR(255)"
+	}
+	if strings.HasSuffix(typ, "UUID") {
+		if dialect == "postgres" {
+			return "UUID"
+		}
+		return "CHAR(36)"
+	}
+	return "TEXT"
+}
+
+func columnDefault(dialect string, f *Field) string {
+	switch {
+	case f.DefaultValue == "time.Now()":
+		return "CURRENT_TIMESTAMP"
+	case f.Version:
+		return "1"
+	case f.Auto && strings.EqualFold(dialect, "postgres") && !isInteger(f.Type):
+		return "gen_random_uuid()"
+	}
+	return ""
+}
@@ -0,0 +1,76 @@
+package typrepo_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/typical-go/typical-rest-server/pkg/typrepo"
+)
+
+func TestCreateTable(t *testing.T) {
+	id := &typrepo.Field{Name: "ID", Type: "int64", Column: "id", PrimaryKey: true, Auto: true}
+	isbn := &typrepo.Field{Name: "ISBN", Type: "string", Column: "isbn", Unique: true}
+	version := &typrepo.Field{Name: "Version", Type: "int64", Column: "version", Version: true, DefaultValue: "1"}
+	updatedAt := &typrepo.Field{Name: "UpdatedAt", Type: "time.Time", Column: "updated_at", DefaultValue: "time.Now()"}
+	deletedAt := &typrepo.Field{Name: "DeletedAt", Type: "*time.Time", Column: "deleted_at", Nullable: true}
+	entity := &typrepo.Entity{
+		Table:       "books",
+		Dialect:     "postgres",
+		Fields:      []*typrepo.Field{id, isbn, version, updatedAt, deletedAt},
+		PrimaryKeys: []*typrepo.Field{id},
+		UniqueKeys:  []*typrepo.Field{isbn},
+	}
+	require.Equal(t, &typrepo.Table{
+		Name: "books",
+		Columns: []*typrepo.Column{
+			{Name: "id", Type: "BIGSERIAL", Auto: true},
+			{Name: "isbn", Type: "VARCHAR(255)"},
+			{Name: "version", Type: "BIGINT", Default: "1"},
+			{Name: "updated_at", Type: "TIMESTAMP", Default: "CURRENT_TIMESTAMP"},
+			{Name: "deleted_at", Type: "TIMESTAMP", Nullable: true},
+		},
+		PrimaryKey: []string{"id"},
+		Unique:     []string{"isbn"},
+	}, typrepo.CreateTable(entity))
+}
+
+func TestCreateTable_ColumnType(t *testing.T) {
+	testcases := []struct {
+		testName string
+		dialect  string
+		field    *typrepo.Field
+		expected string
+	}{
+		{dialect: "postgres", field: &typrepo.Field{Type: "int64", Auto: true}, expected: "BIGSERIAL"},
+		{dialect: "postgres", field: &typrepo.Field{Type: "int", Auto: true}, expected: "SERIAL"},
+		{dialect: "mysql", field: &typrepo.Field{Type: "int64", Auto: true}, expected: "BIGINT"},
+		{dialect: "sqlite", field: &typrepo.Field{Type: "int64", Auto: true}, expected: "INTEGER"},
+		{dialect: "postgres", field: &typrepo.Field{Type: "int16"}, expected: "SMALLINT"},
+		{dialect: "postgres", field: &typrepo.Field{Type: "*int64"}, expected: "BIGINT"},
+		{dialect: "postgres", field: &typrepo.Field{Type: "sql.NullInt64"}, expected: "BIGINT"},
+		{dialect: "postgres", field: &typrepo.Field{Type: "float64"}, expected: "DOUBLE PRECISION"},
+		{dialect: "mysql", field: &typrepo.Field{Type: "float64"}, expected: "DOUBLE"},
+		{dialect: "mysql", field: &typrepo.Field{Type: "float32"}, expected: "REAL"},
+		{dialect: "mysql", field: &typrepo.Field{Type: "bool"}, expected: "BOOLEAN"},
+		{dialect: "mysql", field: &typrepo.Field{Type: "sql.NullTime"}, expected: "TIMESTAMP"},
+		{dialect: "postgres", field: &typrepo.Field{Type: "[]byte"}, expected: "BYTEA"},
+		{dialect: "mysql", field: &typrepo.Field{Type: "[]byte"}, expected: "BLOB"},
+		{dialect: "postgres", field: &typrepo.Field{Type: "string"}, expected: "VARCHAR(255)"},
+		{dialect: "postgres", field: &typrepo.Field{Type: "string", Auto: true}, expected: "UUID"},
+		{dialect: "postgres", field: &typrepo.Field{Type: "uuid.UUID"}, expected: "UUID"},
+		{dialect: "mysql", field: &typrepo.Field{Type: "uuid.UUID"}, expected: "CHAR(36)"},
+		{dialect: "postgres", field: &typrepo.Field{Type: "json.RawMessage"}, expected: "TEXT"},
+		{
+			testName: "column_type tag",
+			dialect:  "postgres",
+			field:    &typrepo.Field{Type: "string", ColumnType: "TEXT"},
+			expected: "TEXT",
+		},
+	}
+	for _, tt := range testcases {
+		t.Run(tt.testName, func(t *testing.T) {
+			table := typrepo.CreateTable(&typrepo.Entity{Dialect: tt.dialect, Fields: []*typrepo.Field{tt.field}})
+			require.Equal(t, tt.expected, table.Columns[0].Type)
+		})
+	}
+}
@@ -0,0 +1,464 @@
+package typrepo
+
+const sqliteTmpl = `package {{.Package}}_repo
+
+import({{range $pkg, $alias := .Imports}}
+	{{$alias}} "{{$pkg}}"{{end}}
+)
+
+var (
+	// {{.Name}}TableName is table name for {{.Table}} entity
+	{{.Name}}TableName = "{{.Table}}"
+	// {{.Name}}Table is columns for {{.Table}} entity
+	{{.Name}}Table = struct {
+		{{range .Fields}}{{.Name}} string
+		{{end}}
+	}{
+		{{range .Fields}}{{.Name}}: "{{.Column}}",
+		{{end}}
+	}
+)
+
+type (
+	// {{.Name}}Repo to get {{.Table}} data from database
+	// @mock
+	{{.Name}}Repo interface {
+		Count(context.Context, ...sqkit.SelectOption) (int64, error)
+		Find(context.Context, ...sqkit.SelectOption) ([]*{{.Package}}.{{.Name}}, error)
+		Each(context.Context, func(*{{.Package}}.{{.Name}}) error, ...sqkit.SelectOption) error{{if .PrimaryKeys}}
+		FindByPK(context.Context, {{.KeyTypes}}) (*{{.Package}}.{{.Name}}, error)
+		DeleteByPK(context.Context, {{.KeyTypes}}) (int64, error){{end}}
+		Create(context.Context, *{{.Package}}.{{.Name}}) ({{.KeyType}}, error)
+		Delete(context.Context, {{if .SoftDelete}}sqkit.SoftDeleteOption{{else}}sqkit.DeleteOption{{end}}) (int64, error)
+		Update(context.Context, *{{.Package}}.{{.Name}}, sqkit.UpdateOption) (int64, error)
+		Patch(context.Context, *{{.Package}}.{{.Name}}, sqkit.UpdateOption, ...string) (int64, error){{if .UpsertKey}}
+		Upsert(context.Context, *{{.Package}}.{{.Name}}) ({{.KeyType}}, error){{end}}{{if .SoftDelete}}
+		Restore(context.Context, sqkit.UpdateOption) (int64, error){{end}}
+	}
+	// {{.Name}}RepoImpl is implementation {{.Table}} repository
+	{{.Name}}RepoImpl struct {
+		dig.In
+		*sql.DB {{.CtorDB}}
+		Replica *sql.DB {{.CtorReplica}}
+	}
+)
+
+func init() {
+	typapp.Provide("",New{{.Name}}Repo)
+}
+
+// New{{.Name}}Repo return new instance of {{.Name}}Repo or error if the database is not available
+func New{{.Name}}Repo(impl {{.Name}}RepoImpl) ({{.Name}}Repo, error) {
+	if impl.DB == nil {
+		return nil, errors.New("{{.Name}}Repo: missing database")
+	}
+	return &impl, nil
+}
+
+{{if .PrimaryKeys}}
+// {{.Name}}PK return condition of {{.Table}} primary key
+func {{.Name}}PK({{.KeyParams}}) sqkit.Eq {
+	return sqkit.Eq{ {{range .PrimaryKeys}}
+		{{$.Name}}Table.{{.Name}}: {{.Param}},{{end}}
+	}
+}
+{{end}}
+// Count {{.Table}}
+func (r *{{.Name}}RepoImpl) Count(ctx context.Context, opts ...sqkit.SelectOption) (int64, error) {
+	builder := sq.
+		Select("count(*)").
+		From({{.Name}}TableName).
+		RunWith(dbtxn.Reader(ctx, r.DB, r.Replica))
+{{if .SoftDelete}}
+	if !sqkit.HasIncludeDeleted(opts) {
+		builder = builder.Where(sq.Eq{ {{.Name}}Table.{{.SoftDelete.Name}}: nil})
+	}
+{{end}}
+	for _, opt := range opts {
+		builder = opt.CompileSelect(builder)
+	}
+
+	row := builder.QueryRowContext(ctx)
+
+	var cnt int64
+	if err := row.Scan(&cnt); err != nil {
+		return -1, err
+	}
+	return cnt, nil
+}
+
+
+// Find {{.Table}}
+func (r *{{.Name}}RepoImpl) Find(ctx context.Context, opts ...sqkit.SelectOption) ([]*{{.Package}}.{{.Name}}, error) {
+	list := make([]*{{.Package}}.{{.Name}}, 0)
+	if err := r.Each(ctx, func(ent *{{.Package}}.{{.Name}}) error {
+		list = append(list, ent)
+		return nil
+	}, opts...); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// Each iterate {{.Table}} row by row without load all rows to memory. The iteration stop when fn return error or context is done
+func (r *{{.Name}}RepoImpl) Each(ctx context.Context, fn func(*{{.Package}}.{{.Name}}) error, opts ...sqkit.SelectOption) error {
+	builder := sq.
+		Select(
+			{{range .Fields}}{{$.Name}}Table.{{.Name}},
+			{{end}}
+		).
+		From({{.Name}}TableName).
+		RunWith(dbtxn.Reader(ctx, r.DB, r.Replica))
+{{if .SoftDelete}}
+	if !sqkit.HasIncludeDeleted(opts) {
+		builder = builder.Where(sq.Eq{ {{.Name}}Table.{{.SoftDelete.Name}}: nil})
+	}
+{{end}}
+	for _, opt := range opts {
+		builder = opt.CompileSelect(builder)
+	}
+
+	rows, err := builder.QueryContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		ent := new({{.Package}}.{{.Name}})
+		if err := rows.Scan({{range .Fields}}
+			&ent.{{.Name}},{{end}}
+		); err != nil {
+			return err
+		}
+		if err := fn(ent); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+{{if .PrimaryKeys}}
+// FindByPK find {{.Table}} by primary key. Return sql.ErrNoRows if not found
+func (r *{{.Name}}RepoImpl) FindByPK(ctx context.Context, {{.KeyParams}}) (*{{.Package}}.{{.Name}}, error) {
+	list, err := r.Find(ctx, {{.Name}}PK({{.KeyArgs}}))
+	if err != nil {
+		return nil, err
+	}
+	if len(list) < 1 {
+		return nil, sql.ErrNoRows
+	}
+	return list[0], nil
+}
+
+// DeleteByPK delete {{.Table}} by primary key
+func (r *{{.Name}}RepoImpl) DeleteByPK(ctx context.Context, {{.KeyParams}}) (int64, error) {
+	return r.Delete(ctx, {{.Name}}PK({{.KeyArgs}}))
+}
+{{end}}
+// Create {{.Table}}{{if not .PrimaryKey}} and return affected row{{end}}
+func (r *{{.Name}}RepoImpl) Create(ctx context.Context, ent *{{.Package}}.{{.Name}}) ({{.KeyType}}, error) {
+	txn, err := dbtxn.Use(ctx, r.DB)
+	if err != nil {
+		return {{.InvalidKey}}, err
+	}
+
+	if err := sqkit.BeforeCreate(ctx, ent); err != nil {
+		txn.SetError(err)
+		return {{.InvalidKey}}, err
+	}
+
+	{{if or .AutoKey (not .PrimaryKey)}}res, err :={{else}}_, err ={{end}} sq.
+		Insert({{$.Name}}TableName).
+		Columns({{range .Fields}}{{if and (not .Auto) (not .SoftDelete)}}	{{$.Name}}Table.{{.Name}},{{end}}	
+		{{end}}).
+		Values({{range .Fields}}{{if .DefaultValue}}	{{.DefaultValue}},{{else if and (not .Auto) (not .SoftDelete)}}	ent.{{.Name}},{{end}}
+		{{end}}).
+		RunWith(txn.DB).
+		ExecContext(ctx)
+
+	if err != nil {
+		txn.SetError(err)
+		return {{.InvalidKey}}, err
+	}
+{{if .PrimaryKey}}{{if .PrimaryKey.Auto}}
+{{if eq .KeyType "int64"}}	id, err := res.LastInsertId(){{else}}	lastInsertID, err := res.LastInsertId()
+	id := {{.KeyType}}(lastInsertID){{end}}
+	if err == nil {
+		ent.{{.PrimaryKey.Name}} = id
+		err = sqkit.AfterCreate(ctx, ent)
+	}
+	txn.SetError(err)
+	return id, err{{else}}
+	err = sqkit.AfterCreate(ctx, ent)
+	txn.SetError(err)
+	return ent.{{.PrimaryKey.Name}}, err{{end}}{{else}}
+	affectedRow, err := res.RowsAffected()
+	if err == nil {
+		err = sqkit.AfterCreate(ctx, ent)
+	}
+	txn.SetError(err)
+	return affectedRow, err{{end}}
+}
+
+{{if .UpsertKey}}
+// Upsert {{.Table}}: insert or update when {{.UpsertKey.Column}} already exist{{if not .PrimaryKey}} and return affected row{{end}}
+func (r *{{.Name}}RepoImpl) Upsert(ctx context.Context, ent *{{.Package}}.{{.Name}}) ({{.KeyType}}, error) {
+	txn, err := dbtxn.Use(ctx, r.DB)
+	if err != nil {
+		return {{.InvalidKey}}, err
+	}
+
+	if err := sqkit.BeforeCreate(ctx, ent); err != nil {
+		txn.SetError(err)
+		return {{.InvalidKey}}, err
+	}
+
+	{{if .PrimaryKey}}_, err ={{else}}res, err :={{end}} sq.
+		Insert({{$.Name}}TableName).
+		Columns({{range .Fields}}{{if and (not .Auto) (not .SoftDelete)}}	{{$.Name}}Table.{{.Name}},{{end}}	
+		{{end}}).
+		Values({{range .Fields}}{{if .DefaultValue}}	{{.DefaultValue}},{{else if and (not .Auto) (not .SoftDelete)}}	ent.{{.Name}},{{end}}
+		{{end}}).
+		Suffix(
+			fmt.Sprintf("ON CONFLICT (%s) DO UPDATE SET %s",
+				{{$.Name}}Table.{{.UpsertKey.Name}},
+				strings.Join([]string{ {{range .Fields}}{{if and (not .PrimaryKey) (not .SkipUpdate)}}
+					fmt.Sprintf("%[1]s = excluded.%[1]s", {{$.Name}}Table.{{.Name}}),{{end}}{{end}}{{if .Version}}
+					fmt.Sprintf("%[1]s = %[1]s + 1", {{$.Name}}Table.{{.Version.Name}}),{{end}}{{if .SoftDelete}}
+					fmt.Sprintf("%s = NULL", {{$.Name}}Table.{{.SoftDelete.Name}}), // restore the soft-deleted row{{end}}
+				}, ", "),
+			),
+		).
+		RunWith(txn.DB).
+		ExecContext(ctx)
+
+	if err != nil {
+		txn.SetError(err)
+		return {{.InvalidKey}}, err
+	}
+{{if .PrimaryKey}}
+	// last_insert_rowid() is not updated when the row is updated
+	var id {{.PrimaryKey.Type}}
+	err = sq.
+		Select({{$.Name}}Table.{{.PrimaryKey.Name}}).
+		From({{$.Name}}TableName).
+		Where(sq.Eq{ {{$.Name}}Table.{{.UpsertKey.Name}}: ent.{{.UpsertKey.Name}}}).
+		RunWith(txn.DB).
+		QueryRowContext(ctx).
+		Scan(&id)
+	if err == nil {
+		ent.{{.PrimaryKey.Name}} = id
+		err = sqkit.AfterCreate(ctx, ent)
+	}
+	txn.SetError(err)
+	return id, err{{else}}
+	affectedRow, err := res.RowsAffected()
+	if err == nil {
+		err = sqkit.AfterCreate(ctx, ent)
+	}
+	txn.SetError(err)
+	return affectedRow, err{{end}}
+}
+{{end}}
+// Update {{.Table}}
+func (r *{{.Name}}RepoImpl) Update(ctx context.Context, ent *{{.Package}}.{{.Name}}, opt sqkit.UpdateOption) (int64, error) {
+	txn, err := dbtxn.Use(ctx, r.DB)
+	if err != nil {
+		return -1, err
+	}
+
+{{if .Version}}	if ent.{{.Version.Name}} == 0 {
+		txn.SetError(sqkit.ErrMissingVersion)
+		return -1, sqkit.ErrMissingVersion
+	}
+
+{{end}}	if err := sqkit.BeforeUpdate(ctx, ent); err != nil {
+		txn.SetError(err)
+		return -1, err
+	}
+
+	builder := sq.
+		Update({{.Name}}TableName).{{range .Fields}}{{if and (not .PrimaryKey) (not .SkipUpdate)}}
+		Set({{$.Name}}Table.{{.Name}},{{if .DefaultValue}}{{.DefaultValue}}{{else}}ent.{{.Name}},{{end}}).{{end}}{{end}}{{if .Version}}
+		Set({{$.Name}}Table.{{.Version.Name}}, sq.Expr({{$.Name}}Table.{{.Version.Name}}+" + 1")).
+		Where(sq.Eq{ {{$.Name}}Table.{{.Version.Name}}: ent.{{.Version.Name}}}).{{end}}{{if .SoftDelete}}
+		Where(sq.Eq{ {{$.Name}}Table.{{.SoftDelete.Name}}: nil}).{{end}}
+		RunWith(txn.DB)
+
+	if opt != nil {
+		builder = opt.CompileUpdate(builder)
+	}
+
+	res, err := builder.ExecContext(ctx)
+	if err != nil {
+		txn.SetError(err)
+		return -1, err
+	}
+	affectedRow, err := res.RowsAffected(){{if .Version}}
+	if err == nil && affectedRow < 1 {
+		err = sqkit.ErrVersionConflict
+	}{{end}}
+	if err == nil {
+		err = sqkit.AfterUpdate(ctx, ent)
+	}
+	txn.SetError(err)
+	return affectedRow, err
+}
+
+// Patch {{.Table}}
+func (r *{{.Name}}RepoImpl) Patch(ctx context.Context, ent *{{.Package}}.{{.Name}}, opt sqkit.UpdateOption, columns ...string) (int64, error) {
+	txn, err := dbtxn.Use(ctx, r.DB)
+	if err != nil {
+		return -1, err
+	}
+
+{{if .Version}}	if ent.{{.Version.Name}} == 0 {
+		txn.SetError(sqkit.ErrMissingVersion)
+		return -1, sqkit.ErrMissingVersion
+	}
+
+{{end}}	if err := sqkit.BeforePatch(ctx, ent, columns); err != nil {
+		txn.SetError(err)
+		return -1, err
+	}
+
+	builder := sq.Update({{.Name}}TableName).RunWith(txn.DB){{if .Version}}
+	builder = builder.
+		Set({{.Name}}Table.{{.Version.Name}}, sq.Expr({{.Name}}Table.{{.Version.Name}}+" + 1")).
+		Where(sq.Eq{ {{.Name}}Table.{{.Version.Name}}: ent.{{.Version.Name}}}){{end}}{{if .SoftDelete}}
+	builder = builder.Where(sq.Eq{ {{.Name}}Table.{{.SoftDelete.Name}}: nil}){{end}}
+	{{range .Fields}}{{if and (not .PrimaryKey) (not .SkipUpdate)}}{{if .DefaultValue}}
+	builder = builder.Set({{$.Name}}Table.{{.Name}}, {{.DefaultValue}}){{else}}
+	if sqkit.ShouldPatch(columns, {{$.Name}}Table.{{.Name}}, ent.{{.Name}}) {
+		builder = builder.Set({{$.Name}}Table.{{.Name}}, ent.{{.Name}})
+	}{{end}}{{end}}{{end}}
+
+	if opt != nil {
+		builder = opt.CompileUpdate(builder)
+	}
+
+	res, err := builder.ExecContext(ctx)
+	if err != nil {
+		txn.SetError(err)
+		return -1, err
+	}
+
+	affectedRow, err := res.RowsAffected(){{if .Version}}
+	if err == nil && affectedRow < 1 {
+		err = sqkit.ErrVersionConflict
+	}{{end}}
+	if err == nil {
+		err = sqkit.AfterPatch(ctx, ent, columns)
+	}
+	txn.SetError(err)
+	return affectedRow, err
+}
+
+{{if .SoftDelete}}
+// Delete {{.Table}} by set {{.SoftDelete.Column}} (soft delete)
+func (r *{{.Name}}RepoImpl) Delete(ctx context.Context, opt sqkit.SoftDeleteOption) (int64, error) {
+	txn, err := dbtxn.Use(ctx, r.DB)
+	if err != nil {
+		return -1, err
+	}
+
+	if err := sqkit.BeforeDelete(ctx, (*{{.Package}}.{{.Name}})(nil), opt); err != nil {
+		txn.SetError(err)
+		return -1, err
+	}
+
+	builder := sq.
+		Update({{.Name}}TableName).
+		Set({{.Name}}Table.{{.SoftDelete.Name}}, time.Now()).
+		Where(sq.Eq{ {{.Name}}Table.{{.SoftDelete.Name}}: nil}).
+		RunWith(txn.DB)
+
+	if opt != nil {
+		builder = opt.CompileUpdate(builder)
+	}
+
+	res, err := builder.ExecContext(ctx)
+	if err != nil {
+		txn.SetError(err)
+		return -1, err
+	}
+
+	affectedRow, err := res.RowsAffected()
+	if err == nil {
+		err = sqkit.AfterDelete(ctx, (*{{.Package}}.{{.Name}})(nil), opt)
+	}
+	txn.SetError(err)
+	return affectedRow, err
+}
+
+// Restore soft-deleted {{.Table}}
+func (r *{{.Name}}RepoImpl) Restore(ctx context.Context, opt sqkit.UpdateOption) (int64, error) {
+	txn, err := dbtxn.Use(ctx, r.DB)
+	if err != nil {
+		return -1, err
+	}
+
+	if err := sqkit.BeforeRestore(ctx, (*{{.Package}}.{{.Name}})(nil), opt); err != nil {
+		txn.SetError(err)
+		return -1, err
+	}
+
+	builder := sq.
+		Update({{.Name}}TableName).
+		Set({{.Name}}Table.{{.SoftDelete.Name}}, nil).
+		Where(sq.NotEq{ {{.Name}}Table.{{.SoftDelete.Name}}: nil}).
+		RunWith(txn.DB)
+
+	if opt != nil {
+		builder = opt.CompileUpdate(builder)
+	}
+
+	res, err := builder.ExecContext(ctx)
+	if err != nil {
+		txn.SetError(err)
+		return -1, err
+	}
+
+	affectedRow, err := res.RowsAffected()
+	if err == nil {
+		err = sqkit.AfterRestore(ctx, (*{{.Package}}.{{.Name}})(nil), opt)
+	}
+	txn.SetError(err)
+	return affectedRow, err
+}
+{{else}}
+// Delete {{.Table}}
+func (r *{{.Name}}RepoImpl) Delete(ctx context.Context, opt sqkit.DeleteOption) (int64, error) {
+	txn, err := dbtxn.Use(ctx, r.DB)
+	if err != nil {
+		return -1, err
+	}
+
+	if err := sqkit.BeforeDelete(ctx, (*{{.Package}}.{{.Name}})(nil), opt); err != nil {
+		txn.SetError(err)
+		return -1, err
+	}
+
+	builder := sq.Delete({{.Name}}TableName).RunWith(txn.DB)
+	if opt != nil {
+		builder = opt.CompileDelete(builder)
+	}
+
+	res, err := builder.ExecContext(ctx)
+	if err != nil {
+		txn.SetError(err)
+		return -1, err
+	}
+
+	affectedRow, err := res.RowsAffected()
+	if err == nil {
+		err = sqkit.AfterDelete(ctx, (*{{.Package}}.{{.Name}})(nil), opt)
+	}
+	txn.SetError(err)
+	return affectedRow, err
+}
+{{end}}`
@@ -0,0 +1,141 @@
+package typrepo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type (
+	dbColumn struct {
+		Type     string
+		Nullable bool
+		Default  string
+	}
+)
+
+// VerifySchema compare the tables with live database (information_schema) and return the problems
+// which break the generated repository at runtime
+func VerifySchema(ctx context.Context, db *sql.DB, dialect string, tables []*Table) ([]string, error) {
+	dbTables, err := introspect(ctx, db, dialect)
+	if err != nil {
+		return nil, err
+	}
+	var problems []string
+	for _, table := range tables {
+		dbColumns, ok := dbTables[table.Name]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("%s: missing table", table.Name))
+			continue
+		}
+		for _, col := range table.Columns {
+			name := table.Name + "." + col.Name
+			dbCol, ok := dbColumns[col.Name]
+			if !ok {
+				problems = append(problems, fmt.Sprintf("%s: missing column (expected %s)", name, col.Type))
+				continue
+			}
+			problems = append(problems, verifyColumn(name, col, dbCol)...)
+		}
+		var colNames []string
+		for colName := range dbColumns {
+			colNames = append(colNames, colName)
+		}
+		sort.Strings(colNames)
+		for _, colName := range colNames {
+			dbCol := dbColumns[colName]
+			if table.Column(colName) == nil && !dbCol.Nullable && dbCol.Default == "" {
+				problems = append(problems, fmt.Sprintf("%s.%s: not null column without default is not in the entity", table.Name, colName))
+			}
+		}
+	}
+	return problems, nil
+}
+
+// EntityTables return tables of the entities which use the database i.e. same ctor_db, or no ctor_db
+// (the unnamed database constructor) with same dialect
+func EntityTables(entities []*Entity, dialect, ctorDB string) []*Table {
+	var tables []*Table
+	for _, entity := range entities {
+		if entity.CtorName == ctorDB || (entity.CtorName == "" && strings.EqualFold(entity.Dialect, dialect)) {
+			tables = append(tables, CreateTable(entity))
+		}
+	}
+	return tables
+}
+
+func verifyColumn(name string, col *Column, dbCol *dbColumn) []string {
+	var problems []string
+	if !compatibleType(col.Type, dbCol.Type) {
+		problems = append(problems, fmt.Sprintf("%s: type mismatch (expected %s, actual %s)", name, col.Type, dbCol.Type))
+	}
+	if dbCol.Nullable && !col.Nullable {
+		problems = append(problems, fmt.Sprintf("%s: nullable in database but the field is not nullable (use pointer or sql.Null*)", name))
+	}
+	if !dbCol.Nullable && col.Nullable {
+		problems = append(problems, fmt.Sprintf("%s: not null in database but the field is nullable (nil value fail the write)", name))
+	}
+	return problems
+}
+
+func introspect(ctx context.Context, db *sql.DB, dialect string) (map[string]map[string]*dbColumn, error) {
+	schema := "current_schema()"
+	if strings.EqualFold(dialect, "mysql") {
+		schema = "DATABASE()"
+	}
+	rows, err := db.QueryContext(ctx, fmt.Sprintf(
+		"SELECT table_name, column_name, data_type, is_nullable, COALESCE(column_default, '') "+
+			"FROM information_schema.columns WHERE table_schema = %s", schema,
+	))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tables := make(map[string]map[string]*dbColumn)
+	for rows.Next() {
+		var table, column, isNullable string
+		col := new(dbColumn)
+		if err := rows.Scan(&table, &column, &col.Type, &isNullable, &col.Default); err != nil {
+			return nil, err
+		}
+		col.Nullable = strings.EqualFold(isNullable, "YES")
+		if tables[table] == nil {
+			tables[table] = make(map[string]*dbColumn)
+		}
+		tables[table][column] = col
+	}
+	return tables, rows.Err()
+}
+
+func compatibleType(expected, actual string) bool {
+	e, a := typeFamily(expected), typeFamily(actual)
+	if e == a {
+		return true
+	}
+	// boolean is stored as tinyint in mysql
+	return (e == "boolean" && a == "integer") || (e == "integer" && a == "boolean")
+}
+
+func typeFamily(typ string) string {
+	typ = strings.ToLower(typ)
+	switch {
+	case strings.Contains(typ, "bool") || typ == "tinyint":
+		return "boolean"
+	case strings.Contains(typ, "int") || strings.Contains(typ, "serial"):
+		return "integer"
+	case strings.Contains(typ, "char") || strings.Contains(typ, "text") ||
+		strings.Contains(typ, "uuid") || strings.Contains(typ, "enum") || strings.Contains(typ, "json"):
+		return "string"
+	case strings.Contains(typ, "time") || strings.Contains(typ, "date"):
+		return "time"
+	case strings.Contains(typ, "double") || strings.Contains(typ, "real") || strings.Contains(typ, "float") ||
+		strings.Contains(typ, "numeric") || strings.Contains(typ, "decimal"):
+		return "float"
+	case strings.Contains(typ, "bytea") || strings.Contains(typ, "blob") || strings.Contains(typ, "binary"):
+		return "bytes"
+	}
+	return typ
+}
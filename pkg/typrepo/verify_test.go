@@ -0,0 +1,112 @@
+package typrepo_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/typical-go/typical-rest-server/pkg/typrepo"
+)
+
+func TestCompatibleType(t *testing.T) {
+	testcases := []struct {
+		expected string
+		actual   string
+		ok       bool
+	}{
+		{expected: "BIGINT", actual: "bigint", ok: true},
+		{expected: "BIGSERIAL", actual: "bigint", ok: true},
+		{expected: "BIGINT", actual: "integer", ok: true},
+		{expected: "VARCHAR(255)", actual: "character varying", ok: true},
+		{expected: "UUID", actual: "uuid", ok: true},
+		{expected: "CHAR(36)", actual: "char", ok: true},
+		{expected: "TIMESTAMP", actual: "timestamp without time zone", ok: true},
+		{expected: "TIMESTAMP", actual: "datetime", ok: true},
+		{expected: "DOUBLE PRECISION", actual: "numeric", ok: true},
+		{expected: "BOOLEAN", actual: "tinyint", ok: true},
+		{expected: "BOOLEAN", actual: "int", ok: true},
+		{expected: "BYTEA", actual: "bytea", ok: true},
+		{expected: "BLOB", actual: "longblob", ok: true},
+		{expected: "BIGINT", actual: "character varying", ok: false},
+		{expected: "VARCHAR(255)", actual: "timestamp", ok: false},
+		{expected: "TIMESTAMP", actual: "bigint", ok: false},
+	}
+	for _, tt := range testcases {
+		t.Run(tt.expected+"/"+tt.actual, func(t *testing.T) {
+			require.Equal(t, tt.ok, typrepo.CompatibleType(tt.expected, tt.actual))
+		})
+	}
+}
+
+func TestEntityTables(t *testing.T) {
+	id := &typrepo.Field{Name: "ID", Type: "int64", Column: "id", PrimaryKey: true, Auto: true}
+	entities := []*typrepo.Entity{
+		{Table: "books", Dialect: "postgres", CtorName: "pg", Fields: []*typrepo.Field{id}},
+		{Table: "authors", Dialect: "postgres", Fields: []*typrepo.Field{id}},
+		{Table: "songs", Dialect: "mysql", CtorName: "mysql", Fields: []*typrepo.Field{id}},
+		{Table: "albums", Dialect: "mysql", Fields: []*typrepo.Field{id}},
+	}
+	testcases := []struct {
+		testName string
+		dialect  string
+		ctorDB   string
+		expected []string
+	}{
+		{dialect: "postgres", ctorDB: "pg", expected: []string{"books", "authors"}},
+		{dialect: "mysql", ctorDB: "mysql", expected: []string{"songs", "albums"}},
+		{testName: "no match", dialect: "postgres", ctorDB: "pg2", expected: []string{"authors"}},
+		{testName: "no entity", dialect: "sqlite", ctorDB: "sqlite"},
+	}
+	for _, tt := range testcases {
+		t.Run(tt.testName, func(t *testing.T) {
+			var names []string
+			for _, table := range typrepo.EntityTables(entities, tt.dialect, tt.ctorDB) {
+				names = append(names, table.Name)
+			}
+			require.Equal(t, tt.expected, names)
+		})
+	}
+}
+
+func TestVerifyColumn(t *testing.T) {
+	testcases := []struct {
+		testName   string
+		col        *typrepo.Column
+		dbType     string
+		dbNullable bool
+		expected   []string
+	}{
+		{
+			col:    &typrepo.Column{Name: "title", Type: "VARCHAR(255)"},
+			dbType: "character varying",
+		},
+		{
+			col:        &typrepo.Column{Name: "deleted_at", Type: "TIMESTAMP", Nullable: true},
+			dbType:     "timestamp without time zone",
+			dbNullable: true,
+		},
+		{
+			testName: "type mismatch",
+			col:      &typrepo.Column{Name: "title", Type: "VARCHAR(255)"},
+			dbType:   "bigint",
+			expected: []string{"books.title: type mismatch (expected VARCHAR(255), actual bigint)"},
+		},
+		{
+			testName:   "nullable in database",
+			col:        &typrepo.Column{Name: "title", Type: "VARCHAR(255)"},
+			dbType:     "character varying",
+			dbNullable: true,
+			expected:   []string{"books.title: nullable in database but the field is not nullable (use pointer or sql.Null*)"},
+		},
+		{
+			testName: "not null in database",
+			col:      &typrepo.Column{Name: "title", Type: "VARCHAR(255)", Nullable: true},
+			dbType:   "character varying",
+			expected: []string{"books.title: not null in database but the field is nullable (nil value fail the write)"},
+		},
+	}
+	for _, tt := range testcases {
+		t.Run(tt.testName, func(t *testing.T) {
+			require.Equal(t, tt.expected, typrepo.VerifyColumn("books."+tt.col.Name, tt.col, tt.dbType, tt.dbNullable))
+		})
+	}
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"log"
+	"os"
 
 	"github.com/typical-go/typical-go/pkg/typapp"
 	"github.com/typical-go/typical-go/pkg/typast"
@@ -12,6 +13,7 @@ import (
 	"github.com/typical-go/typical-rest-server/pkg/dbtool"
 	"github.com/typical-go/typical-rest-server/pkg/dbtool/mysqltool"
 	"github.com/typical-go/typical-rest-server/pkg/dbtool/pgtool"
+	"github.com/typical-go/typical-rest-server/pkg/dbtool/sqlitetool"
 	"github.com/typical-go/typical-rest-server/pkg/typcfg"
 	"github.com/typical-go/typical-rest-server/pkg/typdocker"
 	"github.com/typical-go/typical-rest-server/pkg/typrepo"
@@ -33,9 +35,19 @@ var descriptor = typgo.Descriptor{
 			Annotators: []typast.Annotator{
 				&typapp.CtorAnnotation{},
 				&typrepo.EntityAnnotation{},
-				&typcfg.EnvconfigAnnotation{DotEnv: ".env", UsageDoc: "USAGE.md"},
+				&typcfg.EnvconfigAnnotation{
+					DotEnv:        ".env",
+					UsageDoc:      "USAGE.md",
+					JSONSchema:    "config.schema.json",
+					ConfigExample: "config.example.yaml",
+				},
 			},
 		},
+		// check-config
+		&typcfg.CheckCmd{},
+		// migration
+		&typrepo.MigrationCmd{},
+		&typrepo.ScaffoldCmd{},
 		// run
 		&typgo.RunProject{
 			Before: typgo.BuildCmdRuns{"annotate", "compile"},
@@ -72,6 +84,19 @@ var descriptor = typgo.Descriptor{
 			MigrationSrc: "file://databases/mysqldb/migration",
 			SeedSrc:      "databases/mysqldb/seed",
 		},
+		// sqlite
+		&sqlitetool.SQLiteTool{
+			Name: "sqlite",
+			ConfigFn: func() dbtool.Configurer {
+				cfg, err := config.LoadSqliteCfg()
+				if err != nil {
+					log.Fatal(err)
+				}
+				return cfg
+			},
+			MigrationSrc: "file://databases/sqlitedb/migration",
+			SeedSrc:      "databases/sqlitedb/seed",
+		},
 		// reset
 		&typgo.Command{
 			Name:  "reset",
@@ -79,6 +104,7 @@ var descriptor = typgo.Descriptor{
 			Action: typgo.BuildCmdRuns{
 				"pg.drop", "pg.create", "pg.migrate", "pg.seed",
 				"mysql.drop", "mysql.create", "mysql.migrate", "mysql.seed",
+				"sqlite.drop", "sqlite.create", "sqlite.migrate", "sqlite.seed",
 			},
 		},
 		// release
@@ -91,5 +117,13 @@ var descriptor = typgo.Descriptor{
 }
 
 func main() {
-	typgo.Start(&descriptor)
+	sys := &typgo.BuildSys{Descriptor: &descriptor}
+	for _, cmd := range descriptor.Cmds {
+		sys.Commands = append(sys.Commands, cmd.Command(sys))
+	}
+	app := typgo.Cli(sys)
+	typcfg.DotEnvProfile(app, ".env") // e.g. `DOTENV_PROFILE=test ./typicalw test`
+	if err := app.Run(os.Args); err != nil {
+		log.Fatal(err)
+	}
 }